@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/venus-auth/cmd/jwtclient"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// max length of the arguments and result kept in audit record
+const maxAuditFieldLen = 4096
+
+// fields whose values are not kept in audit record, compared in lower case
+var auditRedactedFields = map[string]struct{}{
+	"token":  {},
+	"secret": {},
+}
+
+// methods need admin permission but not change any state, they are not audited
+var auditReadOnlyMethods = map[string]struct{}{
+	"GetAddress":             {},
+	"ListAddress":            {},
+	"ListAddressBinding":     {},
+	"GetNode":                {},
+	"HasNode":                {},
+	"ListNode":               {},
+	"ListMessage":            {},
+	"ListMessageByAddress":   {},
+	"ListMessageByFromState": {},
+	"ListFailedMessage":      {},
+	"ListBlockedMessage":     {},
+	"ListExternalMessage":    {},
+	"GetQueueDepth":          {},
+	"GetSharedParams":        {},
+	"GetBaseFeeGate":         {},
+	"GetFeeReport":           {},
+	"GetUsageReport":         {},
+	"ListSignPolicy":         {},
+	"ListToken":              {},
+	"ListAuditRecord":        {},
+	"ListAccountLimit":       {},
+	"GetAccountQuota":        {},
+	"ListAccountWeight":      {},
+}
+
+// methods whose results are secrets
+var auditSkipResult = map[string]struct{}{
+	"CreateToken": {},
+}
+
+// newAuditRecord build the record of calling method, args not include context
+func newAuditRecord(ctx context.Context, method string, args []reflect.Value, results []reflect.Value) *types.AuditRecord {
+	account, _ := jwtclient.CtxGetName(ctx)
+	ip, _ := jwtclient.CtxGetTokenLocation(ctx)
+	record := &types.AuditRecord{
+		ID:        types.NewUUID(),
+		Account:   account,
+		IP:        ip,
+		Method:    method,
+		Args:      auditJSON(args),
+		CreatedAt: time.Now(),
+	}
+
+	if len(results) == 0 {
+		return record
+	}
+	if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
+		record.Error = err.Error()
+	}
+	if _, ok := auditSkipResult[method]; !ok {
+		record.Result = auditJSON(results[:len(results)-1])
+	}
+
+	return record
+}
+
+func auditJSON(vals []reflect.Value) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	items := make([]interface{}, 0, len(vals))
+	for _, val := range vals {
+		items = append(items, val.Interface())
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Sprintf("marshal failed %v", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		if data, err = json.Marshal(redactAudit(decoded)); err != nil {
+			return fmt.Sprintf("marshal failed %v", err)
+		}
+	}
+	if len(data) > maxAuditFieldLen {
+		return string(data[:maxAuditFieldLen]) + "..."
+	}
+	return string(data)
+}
+
+func redactAudit(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := auditRedactedFields[strings.ToLower(key)]; ok {
+				v[key] = "***"
+				continue
+			}
+			v[key] = redactAudit(item)
+		}
+	case []interface{}:
+		for idx, item := range v {
+			v[idx] = redactAudit(item)
+		}
+	}
+	return val
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/filecoin-project/venus-auth/cmd/jwtclient"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/api/controller"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type auditImpl struct{}
+
+func (auditImpl) SaveNode(ctx context.Context, node *types.Node) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func (auditImpl) DeleteNode(ctx context.Context, name string) (struct{}, error) {
+	return struct{}{}, xerrors.Errorf("node %s not found", name)
+}
+
+func (auditImpl) GetNode(ctx context.Context, name string) (*types.Node, error) {
+	return &types.Node{Name: name}, nil
+}
+
+func (auditImpl) ListNode(ctx context.Context) ([]*types.Node, error) {
+	return nil, nil
+}
+
+func TestPermissionedProxyAudit(t *testing.T) {
+	var out struct {
+		SaveNode   func(ctx context.Context, node *types.Node) (struct{}, error)
+		DeleteNode func(ctx context.Context, name string) (struct{}, error)
+		GetNode    func(ctx context.Context, name string) (*types.Node, error)
+		ListNode   func(ctx context.Context) ([]*types.Node, error)
+	}
+	permMap := map[string]string{"SaveNode": "admin", "DeleteNode": "admin", "GetNode": "read", "ListNode": "admin"}
+
+	var records []*types.AuditRecord
+	PermissionedProxy(permMap, nil, func(ctx context.Context, record *types.AuditRecord) {
+		records = append(records, record)
+	}, auditImpl{}, &out)
+
+	ctx := jwtclient.CtxWithName(context.Background(), "admin-user")
+	ctx = jwtclient.CtxWithTokenLocation(ctx, "127.0.0.1")
+	adminCtx := auth.WithPerm(ctx, AllPermissions)
+
+	_, err := out.SaveNode(adminCtx, &types.Node{Name: "node1", Token: "secret-token"})
+	assert.NoError(t, err)
+	_, err = out.DeleteNode(adminCtx, "node2")
+	assert.Error(t, err)
+	_, err = out.GetNode(adminCtx, "node1")
+	assert.NoError(t, err)
+	// read only
+	_, err = out.ListNode(adminCtx)
+	assert.NoError(t, err)
+	// denied calls are recorded too
+	_, err = out.SaveNode(auth.WithPerm(ctx, defaultPerms), &types.Node{Name: "node3"})
+	assert.Error(t, err)
+
+	assert.Len(t, records, 3)
+	assert.Equal(t, "SaveNode", records[0].Method)
+	assert.Equal(t, "admin-user", records[0].Account)
+	assert.Equal(t, "127.0.0.1", records[0].IP)
+	assert.Contains(t, records[0].Args, "node1")
+	assert.NotContains(t, records[0].Args, "secret-token")
+	assert.Empty(t, records[0].Error)
+
+	assert.Equal(t, "DeleteNode", records[1].Method)
+	assert.Contains(t, records[1].Error, "node2")
+
+	assert.Equal(t, "SaveNode", records[2].Method)
+	assert.Contains(t, records[2].Error, "missing permission")
+}
+
+func TestAuditReadOnlyMethods(t *testing.T) {
+	for method := range auditReadOnlyMethods {
+		assert.Equal(t, "admin", controller.AuthMap[method], method)
+	}
+}
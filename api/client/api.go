@@ -19,9 +19,12 @@ type IMessager interface {
 	WaitMessage(ctx context.Context, id string, confidence uint64) (*types.Message, error)                                                         //perm:read
 	PushMessage(ctx context.Context, msg *venusTypes.UnsignedMessage, meta *types.MsgMeta) (string, error)                                         //perm:write
 	PushMessageWithId(ctx context.Context, id string, msg *venusTypes.UnsignedMessage, meta *types.MsgMeta) (string, error)                        //perm:write
+	PushSignedMessage(ctx context.Context, msg *venusTypes.SignedMessage, meta *types.MsgMeta) (string, error)                                     //perm:write
 	GetMessageByUid(ctx context.Context, id string) (*types.Message, error)                                                                        //perm:read
 	GetMessageByCid(ctx context.Context, id cid.Cid) (*types.Message, error)                                                                       //perm:read
 	GetMessageBySignedCid(ctx context.Context, cid cid.Cid) (*types.Message, error)                                                                //perm:read
+	ListBroadcastResult(ctx context.Context, signedCid cid.Cid) ([]*types.BroadcastResult, error)                                                  //perm:read
+	ListExternalMessage(ctx context.Context, from address.Address) ([]*types.ExternalMessage, error)                                               //perm:admin
 	GetMessageByUnsignedCid(ctx context.Context, cid cid.Cid) (*types.Message, error)                                                              //perm:read
 	GetMessageByFromAndNonce(ctx context.Context, from address.Address, nonce uint64) (*types.Message, error)                                      //perm:read
 	ListMessage(ctx context.Context) ([]*types.Message, error)                                                                                     //perm:admin
@@ -29,12 +32,18 @@ type IMessager interface {
 	ListMessageByAddress(ctx context.Context, addr address.Address) ([]*types.Message, error)                                                      //perm:admin
 	ListFailedMessage(ctx context.Context) ([]*types.Message, error)                                                                               //perm:admin
 	ListBlockedMessage(ctx context.Context, addr address.Address, d time.Duration) ([]*types.Message, error)                                       //perm:admin
+	GetBaseFeeGate(ctx context.Context) (*types.BaseFeeGate, error)                                                                                //perm:admin
+	ListFeeHistory(ctx context.Context, start, end time.Time) ([]*types.FeeHistory, error)                                                         //perm:read
+	GetFeeReport(ctx context.Context, params *types.FeeReportParams) ([]*types.FeeReport, error)                                                   //perm:admin
+	GetUsageReport(ctx context.Context, params *types.UsageReportParams) ([]*types.AccountUsage, error)                                            //perm:admin
+	GetQueueDepth(ctx context.Context) (*types.QueueDepth, error)                                                                                  //perm:admin
 	UpdateMessageStateByID(ctx context.Context, id string, state types.MessageState) (string, error)                                               //perm:admin
 	UpdateAllFilledMessage(ctx context.Context) (int, error)                                                                                       //perm:admin
 	UpdateFilledMessageByID(ctx context.Context, id string) (string, error)                                                                        //perm:admin
 	ReplaceMessage(ctx context.Context, id string, auto bool, maxFee string, gasLimit int64, gasPremium string, gasFeecap string) (cid.Cid, error) //perm:admin
 	RepublishMessage(ctx context.Context, id string) (struct{}, error)                                                                             //perm:admin
 	MarkBadMessage(ctx context.Context, id string) (struct{}, error)                                                                               //perm:admin
+	UnblockMessage(ctx context.Context, id string) (struct{}, error)                                                                               //perm:admin
 
 	SaveAddress(ctx context.Context, address *types.Address) (types.UUID, error)                                                          //perm:admin
 	GetAddress(ctx context.Context, addr address.Address) (*types.Address, error)                                                         //perm:admin
@@ -46,8 +55,14 @@ type IMessager interface {
 	ForbiddenAddress(ctx context.Context, addr address.Address) (address.Address, error)                                                  //perm:admin
 	ActiveAddress(ctx context.Context, addr address.Address) (address.Address, error)                                                     //perm:admin
 	SetSelectMsgNum(ctx context.Context, addr address.Address, num uint64) (address.Address, error)                                       //perm:admin
+	SetWeight(ctx context.Context, addr address.Address, weight int64) (address.Address, error)                                           //perm:admin
 	SetFeeParams(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap string) (address.Address, error) //perm:admin
+	SetFeeStrategy(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) (address.Address, error)                   //perm:admin
+	SetBaseFeeThreshold(ctx context.Context, addr address.Address, threshold string) (address.Address, error)                             //perm:admin
 	ResetAddress(ctx context.Context, addr address.Address, nonce uint64) (uint64, error)                                                 //perm:admin
+	BindAddress(ctx context.Context, binding *types.AddressBinding) (types.UUID, error)                                                   //perm:admin
+	UnbindAddress(ctx context.Context, account string, addr address.Address) (struct{}, error)                                            //perm:admin
+	ListAddressBinding(ctx context.Context, account string) ([]*types.AddressBinding, error)                                              //perm:admin
 
 	GetSharedParams(ctx context.Context) (*types.SharedParams, error)                  //perm:admin
 	SetSharedParams(ctx context.Context, params *types.SharedParams) (struct{}, error) //perm:admin
@@ -59,6 +74,24 @@ type IMessager interface {
 	ListNode(ctx context.Context) ([]*types.Node, error)              //perm:admin
 	DeleteNode(ctx context.Context, name string) (struct{}, error)    //perm:admin
 
+	SaveSignPolicy(ctx context.Context, policy *types.SignPolicy) (types.UUID, error) //perm:admin
+	ListSignPolicy(ctx context.Context) ([]*types.SignPolicy, error)                  //perm:admin
+	DeleteSignPolicy(ctx context.Context, id types.UUID) (struct{}, error)            //perm:admin
+
+	CreateToken(ctx context.Context, params *types.CreateTokenParams) (string, error) //perm:admin
+	RevokeToken(ctx context.Context, id types.UUID) (struct{}, error)                 //perm:admin
+	ListToken(ctx context.Context) ([]*types.LocalToken, error)                       //perm:admin
+
+	ListAuditRecord(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error) //perm:admin
+
+	SetAccountLimit(ctx context.Context, limit *types.AccountLimit) (struct{}, error)     //perm:admin
+	ListAccountLimit(ctx context.Context) ([]*types.AccountLimit, error)                  //perm:admin
+	DeleteAccountLimit(ctx context.Context, account string) (struct{}, error)             //perm:admin
+	GetAccountQuota(ctx context.Context, account string) (*types.AccountQuota, error)     //perm:admin
+	SetAccountWeight(ctx context.Context, account string, weight int64) (struct{}, error) //perm:admin
+	ListAccountWeight(ctx context.Context) ([]*types.AccountWeight, error)                //perm:admin
+	DeleteAccountWeight(ctx context.Context, account string) (struct{}, error)            //perm:admin
+
 	ResponseEvent(ctx context.Context, resp *gatewayTypes.ResponseEvent) error                                             //perm:write
 	ListenWalletEvent(ctx context.Context, wrp *walletevent.WalletRegisterPolicy) (chan *gatewayTypes.RequestEvent, error) //perm:write
 	SupportNewAccount(ctx context.Context, channelId string, account string) error                                         //perm:write
@@ -76,9 +109,12 @@ type Message struct {
 		WaitMessage              func(ctx context.Context, id string, confidence uint64) (*types.Message, error)
 		PushMessage              func(ctx context.Context, msg *venusTypes.UnsignedMessage, meta *types.MsgMeta) (string, error)
 		PushMessageWithId        func(ctx context.Context, id string, msg *venusTypes.UnsignedMessage, meta *types.MsgMeta) (string, error)
+		PushSignedMessage        func(ctx context.Context, msg *venusTypes.SignedMessage, meta *types.MsgMeta) (string, error)
 		GetMessageByUid          func(ctx context.Context, id string) (*types.Message, error)
 		GetMessageByCid          func(ctx context.Context, id cid.Cid) (*types.Message, error)
 		GetMessageBySignedCid    func(ctx context.Context, cid cid.Cid) (*types.Message, error)
+		ListBroadcastResult      func(ctx context.Context, signedCid cid.Cid) ([]*types.BroadcastResult, error)
+		ListExternalMessage      func(ctx context.Context, from address.Address) ([]*types.ExternalMessage, error)
 		GetMessageByUnsignedCid  func(ctx context.Context, cid cid.Cid) (*types.Message, error)
 		GetMessageByFromAndNonce func(ctx context.Context, from address.Address, nonce uint64) (*types.Message, error)
 		ListMessage              func(ctx context.Context) ([]*types.Message, error)
@@ -86,25 +122,37 @@ type Message struct {
 		ListMessageByFromState   func(ctx context.Context, from address.Address, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error)
 		ListFailedMessage        func(ctx context.Context) ([]*types.Message, error)
 		ListBlockedMessage       func(ctx context.Context, addr address.Address, d time.Duration) ([]*types.Message, error)
+		GetBaseFeeGate           func(ctx context.Context) (*types.BaseFeeGate, error)
+		ListFeeHistory           func(ctx context.Context, start, end time.Time) ([]*types.FeeHistory, error)
+		GetFeeReport             func(ctx context.Context, params *types.FeeReportParams) ([]*types.FeeReport, error)
+		GetUsageReport           func(ctx context.Context, params *types.UsageReportParams) ([]*types.AccountUsage, error)
+		GetQueueDepth            func(ctx context.Context) (*types.QueueDepth, error)
 		UpdateMessageStateByID   func(ctx context.Context, id string, state types.MessageState) (string, error)
 		UpdateAllFilledMessage   func(ctx context.Context) (int, error)
 		UpdateFilledMessageByID  func(ctx context.Context, id string) (string, error)
 		ReplaceMessage           func(ctx context.Context, id string, auto bool, maxFee string, gasLimit int64, gasPremium string, gasFeecap string) (cid.Cid, error)
 		RepublishMessage         func(ctx context.Context, id string) (struct{}, error)
 		MarkBadMessage           func(ctx context.Context, id string) (struct{}, error)
-
-		SaveAddress      func(ctx context.Context, address *types.Address) (types.UUID, error)
-		GetAddress       func(ctx context.Context, addr address.Address) (*types.Address, error)
-		HasAddress       func(ctx context.Context, addr address.Address) (bool, error)
-		WalletHas        func(ctx context.Context, addr address.Address) (bool, error)
-		ListAddress      func(ctx context.Context) ([]*types.Address, error)
-		UpdateNonce      func(ctx context.Context, addr address.Address, nonce uint64) (address.Address, error)
-		DeleteAddress    func(ctx context.Context, addr address.Address) (address.Address, error)
-		ForbiddenAddress func(ctx context.Context, addr address.Address) (address.Address, error)
-		ActiveAddress    func(ctx context.Context, addr address.Address) (address.Address, error)
-		SetSelectMsgNum  func(ctx context.Context, addr address.Address, num uint64) (address.Address, error)
-		SetFeeParams     func(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap string) (address.Address, error)
-		ResetAddress     func(ctx context.Context, addr address.Address, nonce uint64) (uint64, error)
+		UnblockMessage           func(ctx context.Context, id string) (struct{}, error)
+
+		SaveAddress         func(ctx context.Context, address *types.Address) (types.UUID, error)
+		GetAddress          func(ctx context.Context, addr address.Address) (*types.Address, error)
+		HasAddress          func(ctx context.Context, addr address.Address) (bool, error)
+		WalletHas           func(ctx context.Context, addr address.Address) (bool, error)
+		ListAddress         func(ctx context.Context) ([]*types.Address, error)
+		UpdateNonce         func(ctx context.Context, addr address.Address, nonce uint64) (address.Address, error)
+		DeleteAddress       func(ctx context.Context, addr address.Address) (address.Address, error)
+		ForbiddenAddress    func(ctx context.Context, addr address.Address) (address.Address, error)
+		ActiveAddress       func(ctx context.Context, addr address.Address) (address.Address, error)
+		SetSelectMsgNum     func(ctx context.Context, addr address.Address, num uint64) (address.Address, error)
+		SetWeight           func(ctx context.Context, addr address.Address, weight int64) (address.Address, error)
+		SetFeeParams        func(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap string) (address.Address, error)
+		SetFeeStrategy      func(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) (address.Address, error)
+		SetBaseFeeThreshold func(ctx context.Context, addr address.Address, threshold string) (address.Address, error)
+		ResetAddress        func(ctx context.Context, addr address.Address, nonce uint64) (uint64, error)
+		BindAddress         func(ctx context.Context, binding *types.AddressBinding) (types.UUID, error)
+		UnbindAddress       func(ctx context.Context, account string, addr address.Address) (struct{}, error)
+		ListAddressBinding  func(ctx context.Context, account string) ([]*types.AddressBinding, error)
 
 		GetSharedParams     func(context.Context) (*types.SharedParams, error)
 		SetSharedParams     func(context.Context, *types.SharedParams) (struct{}, error)
@@ -116,6 +164,24 @@ type Message struct {
 		ListNode   func(ctx context.Context) ([]*types.Node, error)
 		DeleteNode func(ctx context.Context, name string) (struct{}, error)
 
+		SaveSignPolicy   func(ctx context.Context, policy *types.SignPolicy) (types.UUID, error)
+		ListSignPolicy   func(ctx context.Context) ([]*types.SignPolicy, error)
+		DeleteSignPolicy func(ctx context.Context, id types.UUID) (struct{}, error)
+
+		CreateToken func(ctx context.Context, params *types.CreateTokenParams) (string, error)
+		RevokeToken func(ctx context.Context, id types.UUID) (struct{}, error)
+		ListToken   func(ctx context.Context) ([]*types.LocalToken, error)
+
+		ListAuditRecord func(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error)
+
+		SetAccountLimit     func(ctx context.Context, limit *types.AccountLimit) (struct{}, error)
+		ListAccountLimit    func(ctx context.Context) ([]*types.AccountLimit, error)
+		DeleteAccountLimit  func(ctx context.Context, account string) (struct{}, error)
+		GetAccountQuota     func(ctx context.Context, account string) (*types.AccountQuota, error)
+		SetAccountWeight    func(ctx context.Context, account string, weight int64) (struct{}, error)
+		ListAccountWeight   func(ctx context.Context) ([]*types.AccountWeight, error)
+		DeleteAccountWeight func(ctx context.Context, account string) (struct{}, error)
+
 		ResponseEvent     func(ctx context.Context, resp *gatewayTypes.ResponseEvent) error
 		ListenWalletEvent func(ctx context.Context, wrp *walletevent.WalletRegisterPolicy) (chan *gatewayTypes.RequestEvent, error)
 		SupportNewAccount func(ctx context.Context, channelId string, account string) error
@@ -138,6 +204,10 @@ func (message *Message) PushMessageWithId(ctx context.Context, id string, msg *v
 	return message.Internal.PushMessageWithId(ctx, id, msg, meta)
 }
 
+func (message *Message) PushSignedMessage(ctx context.Context, msg *venusTypes.SignedMessage, meta *types.MsgMeta) (string, error) {
+	return message.Internal.PushSignedMessage(ctx, msg, meta)
+}
+
 func (message *Message) GetMessageByUid(ctx context.Context, id string) (*types.Message, error) {
 	return message.Internal.GetMessageByUid(ctx, id)
 }
@@ -154,6 +224,14 @@ func (message *Message) GetMessageBySignedCid(ctx context.Context, cid cid.Cid)
 	return message.Internal.GetMessageBySignedCid(ctx, cid)
 }
 
+func (message *Message) ListBroadcastResult(ctx context.Context, signedCid cid.Cid) ([]*types.BroadcastResult, error) {
+	return message.Internal.ListBroadcastResult(ctx, signedCid)
+}
+
+func (message *Message) ListExternalMessage(ctx context.Context, from address.Address) ([]*types.ExternalMessage, error) {
+	return message.Internal.ListExternalMessage(ctx, from)
+}
+
 func (message *Message) GetMessageByFromAndNonce(ctx context.Context, from address.Address, nonce uint64) (*types.Message, error) {
 	return message.Internal.GetMessageByFromAndNonce(ctx, from, nonce)
 }
@@ -178,6 +256,26 @@ func (message *Message) ListBlockedMessage(ctx context.Context, addr address.Add
 	return message.Internal.ListBlockedMessage(ctx, addr, d)
 }
 
+func (message *Message) GetBaseFeeGate(ctx context.Context) (*types.BaseFeeGate, error) {
+	return message.Internal.GetBaseFeeGate(ctx)
+}
+
+func (message *Message) ListFeeHistory(ctx context.Context, start, end time.Time) ([]*types.FeeHistory, error) {
+	return message.Internal.ListFeeHistory(ctx, start, end)
+}
+
+func (message *Message) GetFeeReport(ctx context.Context, params *types.FeeReportParams) ([]*types.FeeReport, error) {
+	return message.Internal.GetFeeReport(ctx, params)
+}
+
+func (message *Message) GetUsageReport(ctx context.Context, params *types.UsageReportParams) ([]*types.AccountUsage, error) {
+	return message.Internal.GetUsageReport(ctx, params)
+}
+
+func (message *Message) GetQueueDepth(ctx context.Context) (*types.QueueDepth, error) {
+	return message.Internal.GetQueueDepth(ctx)
+}
+
 func (message *Message) UpdateMessageStateByID(ctx context.Context, id string, state types.MessageState) (string, error) {
 	return message.Internal.UpdateMessageStateByID(ctx, id, state)
 }
@@ -202,6 +300,10 @@ func (message *Message) MarkBadMessage(ctx context.Context, id string) (struct{}
 	return message.Internal.MarkBadMessage(ctx, id)
 }
 
+func (message *Message) UnblockMessage(ctx context.Context, id string) (struct{}, error) {
+	return message.Internal.UnblockMessage(ctx, id)
+}
+
 func (message *Message) WaitMessage(ctx context.Context, id string, confidence uint64) (*types.Message, error) {
 	return message.Internal.WaitMessage(ctx, id, confidence)
 }
@@ -248,14 +350,38 @@ func (message *Message) SetSelectMsgNum(ctx context.Context, addr address.Addres
 	return message.Internal.SetSelectMsgNum(ctx, addr, num)
 }
 
+func (message *Message) SetWeight(ctx context.Context, addr address.Address, weight int64) (address.Address, error) {
+	return message.Internal.SetWeight(ctx, addr, weight)
+}
+
 func (message *Message) ResetAddress(ctx context.Context, addr address.Address, nonce uint64) (uint64, error) {
 	return message.Internal.ResetAddress(ctx, addr, nonce)
 }
 
+func (message *Message) BindAddress(ctx context.Context, binding *types.AddressBinding) (types.UUID, error) {
+	return message.Internal.BindAddress(ctx, binding)
+}
+
+func (message *Message) UnbindAddress(ctx context.Context, account string, addr address.Address) (struct{}, error) {
+	return message.Internal.UnbindAddress(ctx, account, addr)
+}
+
+func (message *Message) ListAddressBinding(ctx context.Context, account string) ([]*types.AddressBinding, error) {
+	return message.Internal.ListAddressBinding(ctx, account)
+}
+
 func (message *Message) SetFeeParams(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap string) (address.Address, error) {
 	return message.Internal.SetFeeParams(ctx, addr, gasOverEstimation, maxFee, maxFeeCap)
 }
 
+func (message *Message) SetFeeStrategy(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) (address.Address, error) {
+	return message.Internal.SetFeeStrategy(ctx, addr, params)
+}
+
+func (message *Message) SetBaseFeeThreshold(ctx context.Context, addr address.Address, threshold string) (address.Address, error) {
+	return message.Internal.SetBaseFeeThreshold(ctx, addr, threshold)
+}
+
 /////// shared params ///////
 
 func (message *Message) GetSharedParams(ctx context.Context) (*types.SharedParams, error) {
@@ -317,3 +443,67 @@ func (message *Message) SetLogLevel(ctx context.Context, level string) error {
 func (message *Message) Send(ctx context.Context, params types.SendParams) (string, error) {
 	return message.Internal.Send(ctx, params)
 }
+
+/////// sign policy ///////
+
+func (message *Message) SaveSignPolicy(ctx context.Context, policy *types.SignPolicy) (types.UUID, error) {
+	return message.Internal.SaveSignPolicy(ctx, policy)
+}
+
+func (message *Message) ListSignPolicy(ctx context.Context) ([]*types.SignPolicy, error) {
+	return message.Internal.ListSignPolicy(ctx)
+}
+
+func (message *Message) DeleteSignPolicy(ctx context.Context, id types.UUID) (struct{}, error) {
+	return message.Internal.DeleteSignPolicy(ctx, id)
+}
+
+/////// auth ///////
+
+func (message *Message) CreateToken(ctx context.Context, params *types.CreateTokenParams) (string, error) {
+	return message.Internal.CreateToken(ctx, params)
+}
+
+func (message *Message) RevokeToken(ctx context.Context, id types.UUID) (struct{}, error) {
+	return message.Internal.RevokeToken(ctx, id)
+}
+
+func (message *Message) ListToken(ctx context.Context) ([]*types.LocalToken, error) {
+	return message.Internal.ListToken(ctx)
+}
+
+/////// audit ///////
+
+func (message *Message) ListAuditRecord(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error) {
+	return message.Internal.ListAuditRecord(ctx, params)
+}
+
+/////// account limit ///////
+
+func (message *Message) SetAccountLimit(ctx context.Context, limit *types.AccountLimit) (struct{}, error) {
+	return message.Internal.SetAccountLimit(ctx, limit)
+}
+
+func (message *Message) ListAccountLimit(ctx context.Context) ([]*types.AccountLimit, error) {
+	return message.Internal.ListAccountLimit(ctx)
+}
+
+func (message *Message) DeleteAccountLimit(ctx context.Context, account string) (struct{}, error) {
+	return message.Internal.DeleteAccountLimit(ctx, account)
+}
+
+func (message *Message) GetAccountQuota(ctx context.Context, account string) (*types.AccountQuota, error) {
+	return message.Internal.GetAccountQuota(ctx, account)
+}
+
+func (message *Message) SetAccountWeight(ctx context.Context, account string, weight int64) (struct{}, error) {
+	return message.Internal.SetAccountWeight(ctx, account, weight)
+}
+
+func (message *Message) ListAccountWeight(ctx context.Context) ([]*types.AccountWeight, error) {
+	return message.Internal.ListAccountWeight(ctx)
+}
+
+func (message *Message) DeleteAccountWeight(ctx context.Context, account string) (struct{}, error) {
+	return message.Internal.DeleteAccountWeight(ctx, account)
+}
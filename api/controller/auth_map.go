@@ -46,4 +46,33 @@ var AuthMap = map[string]string{
 	"UpdateAllFilledMessage":   "admin",
 	"SetSelectMsgNum":          "admin",
 	"Send":                     "admin",
+	"SetFeeStrategy":           "admin",
+	"GetBaseFeeGate":           "admin",
+	"SetBaseFeeThreshold":      "admin",
+	"ListFeeHistory":           "read",
+	"GetFeeReport":             "admin",
+	"GetUsageReport":           "admin",
+	"BindAddress":              "admin",
+	"UnbindAddress":            "admin",
+	"ListAddressBinding":       "admin",
+	"SaveSignPolicy":           "admin",
+	"ListSignPolicy":           "admin",
+	"DeleteSignPolicy":         "admin",
+	"CreateToken":              "admin",
+	"RevokeToken":              "admin",
+	"ListToken":                "admin",
+	"ListAuditRecord":          "admin",
+	"SetAccountLimit":          "admin",
+	"ListAccountLimit":         "admin",
+	"DeleteAccountLimit":       "admin",
+	"GetAccountQuota":          "admin",
+	"SetAccountWeight":         "admin",
+	"ListAccountWeight":        "admin",
+	"DeleteAccountWeight":      "admin",
+	"SetWeight":                "admin",
+	"GetQueueDepth":            "admin",
+	"ListBroadcastResult":      "read",
+	"ListExternalMessage":      "admin",
+	"PushSignedMessage":        "write",
+	"UnblockMessage":           "admin",
 }
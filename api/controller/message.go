@@ -100,3 +100,7 @@ func (message Message) RepublishMessage(ctx context.Context, id string) (struct{
 func (message Message) MarkBadMessage(ctx context.Context, id string) (struct{}, error) {
 	return message.MsgService.MarkBadMessage(ctx, id)
 }
+
+func (message Message) UnblockMessage(ctx context.Context, id string) (struct{}, error) {
+	return message.MsgService.UnblockMessage(ctx, id)
+}
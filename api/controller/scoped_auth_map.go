@@ -0,0 +1,20 @@
+package controller
+
+// ScopedAuthMap lowers the permission of methods which only access the data of caller's account
+// when account scoped mode enabled, the rest use AuthMap
+var ScopedAuthMap = map[string]string{
+	"ListMessage":             "read",
+	"ListMessageByFromState":  "read",
+	"ListMessageByAddress":    "read",
+	"ListFailedMessage":       "read",
+	"ListBlockedMessage":      "read",
+	"UpdateFilledMessageByID": "write",
+	"ReplaceMessage":          "write",
+	"RepublishMessage":        "write",
+	"MarkBadMessage":          "write",
+	"GetAddress":              "read",
+	"ListAddress":             "read",
+	"GetFeeReport":            "read",
+	"GetUsageReport":          "read",
+	"GetAccountQuota":         "read",
+}
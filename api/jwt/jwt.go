@@ -1,24 +1,88 @@
 package jwt
 
 import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/venus-auth/auth"
 	"github.com/filecoin-project/venus-auth/cmd/jwtclient"
+	"github.com/filecoin-project/venus-auth/core"
+	jwt3 "github.com/gbrlsnchs/jwt/v3"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
 )
 
 type JwtClient struct {
 	Local, Remote jwtclient.IJwtAuthClient
+
+	local *localJwtClient
+	repo  repo.Repo
 }
 
-func NewJwtClient(jwtCfg *config.JWTConfig) (*JwtClient, error) {
+func NewJwtClient(jwtCfg *config.JWTConfig, repo repo.Repo) (*JwtClient, error) {
 	var err error
 	jc := &JwtClient{
 		Remote: newRemoteJwtClient(jwtCfg),
+		repo:   repo,
 	}
-	if jc.Local, err = newLocalJWTClient(jwtCfg); err != nil {
+	if jc.local, err = newLocalJWTClient(jwtCfg, repo.TokenRepo()); err != nil {
 		return nil, xerrors.Errorf("new local jwt client failed %v", err)
 	}
+	jc.Local = jc.local
 
 	return jc, nil
 }
+
+// CreateToken create a token signed by local secret, it can be revoked by RevokeToken
+func (jc *JwtClient) CreateToken(ctx context.Context, params *types.CreateTokenParams) (string, error) {
+	if len(params.Name) == 0 {
+		return "", xerrors.New("empty name")
+	}
+	if err := core.ContainsPerm(params.Perm); err != nil {
+		return "", xerrors.Errorf("%s %v", params.Perm, err)
+	}
+
+	now := time.Now()
+	localToken := &types.LocalToken{
+		ID:        types.NewUUID(),
+		Name:      params.Name,
+		Perm:      params.Perm,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	payload := localPayload{
+		JWTPayload: auth.JWTPayload{
+			Name: params.Name,
+			Perm: params.Perm,
+		},
+		ID: localToken.ID.String(),
+	}
+	if params.Expire > 0 {
+		localToken.ExpireAt = now.Add(params.Expire)
+		payload.ExpireAt = localToken.ExpireAt.Unix()
+	}
+
+	token, err := jwt3.Sign(payload, jc.local.alg)
+	if err != nil {
+		return "", err
+	}
+	if err := jc.repo.TokenRepo().SaveToken(ctx, localToken); err != nil {
+		return "", err
+	}
+
+	return string(token), nil
+}
+
+func (jc *JwtClient) RevokeToken(ctx context.Context, id types.UUID) (struct{}, error) {
+	if _, err := jc.repo.TokenRepo().GetToken(ctx, id); err != nil {
+		return struct{}{}, xerrors.Errorf("get token %s %w", id, err)
+	}
+	return struct{}{}, jc.repo.TokenRepo().RevokeToken(ctx, id)
+}
+
+func (jc *JwtClient) ListToken(ctx context.Context) ([]*types.LocalToken, error) {
+	return jc.repo.TokenRepo().ListToken(ctx)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"github.com/filecoin-project/venus-auth/cmd/jwtclient"
 
@@ -16,14 +17,27 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
 )
 
+var _ jwtclient.IJwtAuthClient = (*localJwtClient)(nil)
+
 type localJwtClient struct {
-	alg *jwt3.HMACSHA
+	alg       *jwt3.HMACSHA
+	tokenRepo repo.TokenRepo
+}
+
+// localPayload is the payload of tokens created by `auth create-token`, the token in config has no id,
+// it never expires and can not be revoked
+type localPayload struct {
+	auth.JWTPayload
+	ID       string `json:"jti,omitempty"`
+	ExpireAt int64  `json:"exp,omitempty"`
 }
 
-func newLocalJWTClient(cfg *config.JWTConfig) (jwtclient.IJwtAuthClient, error) {
-	lc := &localJwtClient{}
+func newLocalJWTClient(cfg *config.JWTConfig, tokenRepo repo.TokenRepo) (*localJwtClient, error) {
+	lc := &localJwtClient{tokenRepo: tokenRepo}
 
 	if len(cfg.Local.Secret) == 0 {
 		return nil, xerrors.Errorf("secret is empty")
@@ -38,11 +52,30 @@ func newLocalJWTClient(cfg *config.JWTConfig) (jwtclient.IJwtAuthClient, error)
 }
 
 func (c *localJwtClient) Verify(ctx context.Context, token string) ([]auth2.Permission, error) {
-	var payload auth.JWTPayload
+	var payload localPayload
 	_, err := jwt3.Verify([]byte(token), c.alg, &payload)
 	if err != nil {
 		return nil, err
 	}
+	if payload.ExpireAt > 0 && time.Now().Unix() >= payload.ExpireAt {
+		return nil, xerrors.Errorf("token %s expired", payload.Name)
+	}
+	if len(payload.ID) > 0 {
+		id, err := types.ParseUUID(payload.ID)
+		if err != nil || id.IsEmpty() {
+			return nil, xerrors.Errorf("invalid token id %s", payload.ID)
+		}
+		localToken, err := c.tokenRepo.GetToken(ctx, id)
+		if err != nil {
+			return nil, xerrors.Errorf("get token %s failed %v", id, err)
+		}
+		if localToken.Revoked {
+			return nil, xerrors.Errorf("token %s revoked", id)
+		}
+		if !localToken.ExpireAt.IsZero() && !time.Now().Before(localToken.ExpireAt) {
+			return nil, xerrors.Errorf("token %s expired", id)
+		}
+	}
 	return core.AdaptOldStrategy(payload.Perm), nil
 }
 
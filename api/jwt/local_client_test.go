@@ -0,0 +1,96 @@
+package jwt
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/venus-auth/auth"
+	jwt3 "github.com/gbrlsnchs/jwt/v3"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type memTokenRepo struct {
+	tokens map[types.UUID]*types.LocalToken
+}
+
+func (r *memTokenRepo) SaveToken(ctx context.Context, token *types.LocalToken) error {
+	r.tokens[token.ID] = token
+	return nil
+}
+
+func (r *memTokenRepo) GetToken(ctx context.Context, id types.UUID) (*types.LocalToken, error) {
+	token, ok := r.tokens[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+func (r *memTokenRepo) ListToken(ctx context.Context) ([]*types.LocalToken, error) {
+	var list []*types.LocalToken
+	for _, token := range r.tokens {
+		list = append(list, token)
+	}
+	return list, nil
+}
+
+func (r *memTokenRepo) RevokeToken(ctx context.Context, id types.UUID) error {
+	r.tokens[id].Revoked = true
+	return nil
+}
+
+func TestLocalJwtClientVerify(t *testing.T) {
+	ctx := context.Background()
+	secret, adminToken, err := GenSecretAndToken()
+	assert.NoError(t, err)
+
+	tokenRepo := &memTokenRepo{tokens: make(map[types.UUID]*types.LocalToken)}
+	cfg := &config.JWTConfig{}
+	cfg.Local.Secret = hex.EncodeToString(secret)
+	lc, err := newLocalJWTClient(cfg, tokenRepo)
+	assert.NoError(t, err)
+
+	// the token in config has no id and never expires
+	perms, err := lc.Verify(ctx, string(adminToken))
+	assert.NoError(t, err)
+	assert.Contains(t, perms, "admin")
+
+	newToken := func(expire time.Duration) (types.UUID, string) {
+		localToken := &types.LocalToken{ID: types.NewUUID(), Name: "script", Perm: "write"}
+		payload := localPayload{JWTPayload: auth.JWTPayload{Name: "script", Perm: "write"}, ID: localToken.ID.String()}
+		if expire != 0 {
+			localToken.ExpireAt = time.Now().Add(expire)
+			payload.ExpireAt = localToken.ExpireAt.Unix()
+		}
+		assert.NoError(t, tokenRepo.SaveToken(ctx, localToken))
+		token, err := jwt3.Sign(payload, lc.alg)
+		assert.NoError(t, err)
+		return localToken.ID, string(token)
+	}
+
+	id, token := newToken(time.Hour)
+	perms, err = lc.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Contains(t, perms, "write")
+	assert.NotContains(t, perms, "admin")
+
+	assert.NoError(t, tokenRepo.RevokeToken(ctx, id))
+	_, err = lc.Verify(ctx, token)
+	assert.Error(t, err)
+
+	_, token = newToken(-time.Hour)
+	_, err = lc.Verify(ctx, token)
+	assert.Error(t, err)
+
+	// signed by local secret but not created by messager
+	unknown, err := jwt3.Sign(localPayload{JWTPayload: auth.JWTPayload{Name: "script", Perm: "admin"}, ID: types.NewUUID().String()}, lc.alg)
+	assert.NoError(t, err)
+	_, err = lc.Verify(ctx, string(unknown))
+	assert.Error(t, err)
+}
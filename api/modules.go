@@ -17,14 +17,20 @@ import (
 	"github.com/filecoin-project/venus-messager/api/client"
 	"github.com/filecoin-project/venus-messager/api/controller"
 	"github.com/filecoin-project/venus-messager/api/jwt"
+	"github.com/filecoin-project/venus-messager/config"
 	"github.com/filecoin-project/venus-messager/gateway"
 	"github.com/filecoin-project/venus-messager/log"
 	"github.com/filecoin-project/venus-messager/service"
+	"github.com/filecoin-project/venus-messager/types"
 )
 
-func RunAPI(lc fx.Lifecycle, jwtCli *jwt.JwtClient, lst net.Listener, log *log.Logger, msgImp *MessageImp) error {
+func RunAPI(lc fx.Lifecycle, jwtCli *jwt.JwtClient, lst net.Listener, log *log.Logger, msgImp *MessageImp, apiCfg *config.APIConfig) error {
 	var msgAPI client.Message
-	PermissionedProxy(controller.AuthMap, msgImp, &msgAPI.Internal)
+	var scopedPermMap map[string]string
+	if apiCfg.AccountScoped {
+		scopedPermMap = controller.ScopedAuthMap
+	}
+	PermissionedProxy(controller.AuthMap, scopedPermMap, msgImp.RecordAudit, msgImp, &msgAPI.Internal)
 
 	srv := jsonrpc.NewServer()
 	srv.Register("Message", &msgAPI)
@@ -60,6 +66,10 @@ type ImplParams struct {
 	MessageService      *service.MessageService
 	NodeService         *service.NodeService
 	SharedParamsService *service.SharedParamsService
+	SignPolicyService   *service.SignPolicyService
+	AuditService        *service.AuditService
+	AccountLimitService *service.AccountLimitService
+	JwtClient           *jwt.JwtClient
 	GatewayService      *gateway.GatewayService `optional:"true"`
 	Logger              *log.Logger
 }
@@ -69,6 +79,10 @@ type MessageImp struct {
 	*service.MessageService
 	*service.NodeService
 	*service.SharedParamsService
+	*service.SignPolicyService
+	*service.AuditService
+	*service.AccountLimitService
+	*jwt.JwtClient
 	*gateway.GatewayService
 	*log.Logger
 }
@@ -81,6 +95,10 @@ func NewMessageImp(implParams ImplParams) *MessageImp {
 		MessageService:      implParams.MessageService,
 		NodeService:         implParams.NodeService,
 		SharedParamsService: implParams.SharedParamsService,
+		SignPolicyService:   implParams.SignPolicyService,
+		AuditService:        implParams.AuditService,
+		AccountLimitService: implParams.AccountLimitService,
+		JwtClient:           implParams.JwtClient,
 		GatewayService:      implParams.GatewayService,
 		Logger:              implParams.Logger,
 	}
@@ -89,7 +107,14 @@ func NewMessageImp(implParams ImplParams) *MessageImp {
 var AllPermissions = []auth.Permission{"read", "write", "sign", "admin"}
 var defaultPerms = []auth.Permission{"read"}
 
-func PermissionedProxy(permMap map[string]string, in interface{}, out interface{}) {
+// PermissionedProxy check the permission of caller, if scopedPermMap not nil, non-admin callers are
+// limited to the data of their own account and use the permission in scopedPermMap first,
+// if audit not nil, calls of methods which need admin permission and may change state are recorded by it
+func PermissionedProxy(permMap map[string]string,
+	scopedPermMap map[string]string,
+	audit func(ctx context.Context, record *types.AuditRecord),
+	in interface{},
+	out interface{}) {
 	rint := reflect.ValueOf(out).Elem()
 	ra := reflect.ValueOf(in)
 
@@ -101,25 +126,46 @@ func PermissionedProxy(permMap map[string]string, in interface{}, out interface{
 		if !ok {
 			panic(fmt.Sprintf("'%s' not found perm", field.Name))
 		}
+		scopedPerm, hasScopedPerm := scopedPermMap[field.Name]
+		_, readOnly := auditReadOnlyMethods[field.Name]
 
 		rint.Field(f).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) (results []reflect.Value) {
 			ctx := args[0].Interface().(context.Context)
-			if auth.HasPerm(ctx, defaultPerms, requiredPerm) {
-				return fn.Call(args)
+			if audit != nil && requiredPerm == "admin" && !readOnly {
+				defer func() {
+					audit(ctx, newAuditRecord(ctx, field.Name, args[1:], results))
+				}()
 			}
-
-			err := xerrors.Errorf("missing permission to invoke '%s', need '%s'", field.Name, requiredPerm)
-			rerr := reflect.ValueOf(&err).Elem()
-
-			if field.Type.NumOut() == 2 {
-				return []reflect.Value{
-					reflect.Zero(field.Type.Out(0)),
-					rerr,
+			perm := requiredPerm
+			if scopedPermMap != nil && !auth.HasPerm(ctx, defaultPerms, "admin") {
+				account, _ := jwtclient.CtxGetName(ctx)
+				if len(account) == 0 {
+					return errorResults(field.Type, xerrors.Errorf("account not found in token"))
 				}
-			} else {
-				return []reflect.Value{rerr}
+				if hasScopedPerm {
+					perm = scopedPerm
+				}
+				args[0] = reflect.ValueOf(service.WithScopedAccount(ctx, account))
+			}
+
+			if auth.HasPerm(ctx, defaultPerms, perm) {
+				return fn.Call(args)
 			}
+
+			return errorResults(field.Type, xerrors.Errorf("missing permission to invoke '%s', need '%s'", field.Name, perm))
 		}))
 
 	}
 }
+
+func errorResults(fnType reflect.Type, err error) []reflect.Value {
+	rerr := reflect.ValueOf(&err).Elem()
+
+	if fnType.NumOut() == 2 {
+		return []reflect.Value{
+			reflect.Zero(fnType.Out(0)),
+			rerr,
+		}
+	}
+	return []reflect.Value{rerr}
+}
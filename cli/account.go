@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/cli/tablewriter"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+var AccountCmds = &cli.Command{
+	Name:  "account",
+	Usage: "manage the limits and weights of accounts",
+	Subcommands: []*cli.Command{
+		setAccountLimitCmd,
+		listAccountLimitCmd,
+		delAccountLimitCmd,
+		accountQuotaCmd,
+		setAccountWeightCmd,
+		listAccountWeightCmd,
+		delAccountWeightCmd,
+	},
+}
+
+var setAccountLimitCmd = &cli.Command{
+	Name:      "set-limit",
+	Usage:     "set the limit of account, use '" + types.DefaultLimitAccount + "' as account to set the default limit",
+	ArgsUsage: "account",
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "push-per-minute",
+			Usage: "max messages pushed in one minute, 0 means unlimited",
+		},
+		&cli.Int64Flag{
+			Name:  "max-pending",
+			Usage: "max messages not on chain, 0 means unlimited",
+		},
+		&cli.Int64Flag{
+			Name:  "max-params-bytes",
+			Usage: "max bytes of params of one message, 0 means unlimited",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass account")
+		}
+
+		limit := &types.AccountLimit{
+			Account:        ctx.Args().First(),
+			PushPerMinute:  ctx.Int64("push-per-minute"),
+			MaxPendingMsg:  ctx.Int64("max-pending"),
+			MaxParamsBytes: ctx.Int64("max-params-bytes"),
+		}
+		if _, err := client.SetAccountLimit(ctx.Context, limit); err != nil {
+			return err
+		}
+		fmt.Println("set account limit success!")
+
+		return nil
+	},
+}
+
+var listAccountLimitCmd = &cli.Command{
+	Name:  "list-limit",
+	Usage: "list the limits of accounts",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		limits, err := client.ListAccountLimit(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		limitTw := tablewriter.New(
+			tablewriter.Col("Account"),
+			tablewriter.Col("PushPerMinute"),
+			tablewriter.Col("MaxPending"),
+			tablewriter.Col("MaxParamsBytes"),
+			tablewriter.Col("UpdatedAt"),
+		)
+		for _, limit := range limits {
+			limitTw.Write(map[string]interface{}{
+				"Account":        limit.Account,
+				"PushPerMinute":  limit.PushPerMinute,
+				"MaxPending":     limit.MaxPendingMsg,
+				"MaxParamsBytes": limit.MaxParamsBytes,
+				"UpdatedAt":      limit.UpdatedAt.Format(timeLayout),
+			})
+		}
+
+		buf := new(bytes.Buffer)
+		if err := limitTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
+
+var delAccountLimitCmd = &cli.Command{
+	Name:      "del-limit",
+	Usage:     "delete the limit of account, the default limit applies to it after deleted",
+	ArgsUsage: "account",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass account")
+		}
+
+		if _, err := client.DeleteAccountLimit(ctx.Context, ctx.Args().First()); err != nil {
+			return err
+		}
+		fmt.Println("delete account limit success!")
+
+		return nil
+	},
+}
+
+var accountQuotaCmd = &cli.Command{
+	Name:      "quota",
+	Usage:     "show the limit and current usage of account, the account of token by default",
+	ArgsUsage: "[account]",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		quota, err := client.GetAccountQuota(ctx.Context, ctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(quota, " ", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+
+		return nil
+	},
+}
+
+var setAccountWeightCmd = &cli.Command{
+	Name:      "set-weight",
+	Usage:     "set the share of account in messages selected from an address shared by accounts, take effect when fairShare of shared params enabled",
+	ArgsUsage: "account weight",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if ctx.NArg() != 2 {
+			return xerrors.Errorf("must pass account and weight")
+		}
+		weight, err := strconv.ParseInt(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("invalid weight %s %v", ctx.Args().Get(1), err)
+		}
+
+		if _, err := client.SetAccountWeight(ctx.Context, ctx.Args().First(), weight); err != nil {
+			return err
+		}
+		fmt.Println("set account weight success!")
+
+		return nil
+	},
+}
+
+var listAccountWeightCmd = &cli.Command{
+	Name:  "list-weight",
+	Usage: "list the weights of accounts, accounts not listed use weight 1",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		weights, err := client.ListAccountWeight(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		weightTw := tablewriter.New(
+			tablewriter.Col("Account"),
+			tablewriter.Col("Weight"),
+			tablewriter.Col("UpdatedAt"),
+		)
+		for _, weight := range weights {
+			weightTw.Write(map[string]interface{}{
+				"Account":   weight.Account,
+				"Weight":    weight.Weight,
+				"UpdatedAt": weight.UpdatedAt.Format(timeLayout),
+			})
+		}
+
+		buf := new(bytes.Buffer)
+		if err := weightTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
+
+var delAccountWeightCmd = &cli.Command{
+	Name:      "del-weight",
+	Usage:     "delete the weight of account, it uses weight 1 after deleted",
+	ArgsUsage: "account",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass account")
+		}
+
+		if _, err := client.DeleteAccountWeight(ctx.Context, ctx.Args().First()); err != nil {
+			return err
+		}
+		fmt.Println("delete account weight success!")
+
+		return nil
+	},
+}
@@ -3,10 +3,16 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
 )
 
 var AddrCmds = &cli.Command{
@@ -20,8 +26,14 @@ var AddrCmds = &cli.Command{
 		forbiddenAddrCmd,
 		activeAddrCmd,
 		setAddrSelMsgNumCmd,
+		setAddrWeightCmd,
 		setFeeParamsCmd,
+		setFeeStrategyCmd,
+		setBaseFeeThresholdCmd,
 		resetAddrCmd,
+		bindAddrCmd,
+		unbindAddrCmd,
+		listAddrBindingCmd,
 	},
 }
 
@@ -254,6 +266,36 @@ var setAddrSelMsgNumCmd = &cli.Command{
 	},
 }
 
+var setAddrWeightCmd = &cli.Command{
+	Name:      "set-weight",
+	Usage:     "set the weight of address, addresses share the max sign per epoch of shared params by weight",
+	ArgsUsage: "address weight",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if ctx.NArg() != 2 {
+			return xerrors.Errorf("must pass address and weight")
+		}
+		addr, err := address.NewFromString(ctx.Args().First())
+		if err != nil {
+			return err
+		}
+		weight, err := strconv.ParseInt(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return xerrors.Errorf("invalid weight %s %v", ctx.Args().Get(1), err)
+		}
+		if _, err := client.SetWeight(ctx.Context, addr, weight); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
+
 var setFeeParamsCmd = &cli.Command{
 	Name:      "set-fee-params",
 	Usage:     "Address setting fee associated configuration",
@@ -294,6 +336,88 @@ var setFeeParamsCmd = &cli.Command{
 	},
 }
 
+var setFeeStrategyCmd = &cli.Command{
+	Name:      "set-fee-strategy",
+	Usage:     "Address setting the strategy of gas premium and fee cap, empty strategy means use the global one",
+	ArgsUsage: "address",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "strategy",
+			Usage: "fee strategy, one of node-estimate, fixed-premium, basefee-multiplier, premium-percentile",
+		},
+		&cli.StringFlag{
+			Name:  "fixed-premium",
+			Usage: "premium used by fixed-premium strategy (attoFIL/GasUnit)",
+		},
+		&cli.Float64Flag{
+			Name:  "basefee-multiplier",
+			Usage: "fee cap is capped at base fee * multiplier by basefee-multiplier strategy",
+		},
+		&cli.IntFlag{
+			Name:  "premium-percentile",
+			Usage: "percentile of recent premiums used by premium-percentile strategy",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass address")
+		}
+
+		addr, err := address.NewFromString(ctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		params := &types.FeeStrategyParams{
+			Strategy:          ctx.String("strategy"),
+			BaseFeeMultiplier: ctx.Float64("basefee-multiplier"),
+			PremiumPercentile: ctx.Int("premium-percentile"),
+		}
+		if ctx.IsSet("fixed-premium") {
+			params.FixedPremium, err = venusTypes.BigFromString(ctx.String("fixed-premium"))
+			if err != nil {
+				return xerrors.Errorf("parsing fixed-premium: %v", err)
+			}
+		}
+
+		_, err = client.SetFeeStrategy(ctx.Context, addr, params)
+
+		return err
+	},
+}
+
+var setBaseFeeThresholdCmd = &cli.Command{
+	Name:      "set-basefee-threshold",
+	Usage:     "Address setting the base fee threshold, non-urgent messages are held while base fee above it, 0 means use the global one",
+	ArgsUsage: "<address> <threshold>",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if ctx.Args().Len() != 2 {
+			return xerrors.Errorf("must pass address and threshold")
+		}
+
+		addr, err := address.NewFromString(ctx.Args().First())
+		if err != nil {
+			return err
+		}
+
+		_, err = client.SetBaseFeeThreshold(ctx.Context, addr, ctx.Args().Get(1))
+
+		return err
+	},
+}
+
 var resetAddrCmd = &cli.Command{
 	Name:      "reset",
 	Usage:     "reset address nonce",
@@ -334,3 +458,111 @@ var resetAddrCmd = &cli.Command{
 		return nil
 	},
 }
+
+var bindAddrCmd = &cli.Command{
+	Name:      "bind",
+	Usage:     "allow account to push messages from address, the address will be added if not exists",
+	ArgsUsage: "<account> <address>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "methods",
+			Usage: "comma separated method numbers the account may call, eg. 2,3, empty means all methods",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if ctx.Args().Len() != 2 {
+			return xerrors.Errorf("must pass account and address")
+		}
+
+		addr, err := address.NewFromString(ctx.Args().Get(1))
+		if err != nil {
+			return err
+		}
+
+		binding := &types.AddressBinding{
+			Account: ctx.Args().First(),
+			Addr:    addr,
+		}
+		if len(ctx.String("methods")) > 0 {
+			for _, str := range strings.Split(ctx.String("methods"), ",") {
+				m, err := strconv.ParseUint(strings.TrimSpace(str), 10, 64)
+				if err != nil {
+					return xerrors.Errorf("parsing method %s: %v", str, err)
+				}
+				binding.Methods = append(binding.Methods, abi.MethodNum(m))
+			}
+		}
+
+		if _, err := client.BindAddress(ctx.Context, binding); err != nil {
+			return err
+		}
+		fmt.Println("bind address success!")
+
+		return nil
+	},
+}
+
+var unbindAddrCmd = &cli.Command{
+	Name:      "unbind",
+	Usage:     "forbid account to push messages from address",
+	ArgsUsage: "<account> <address>",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if ctx.Args().Len() != 2 {
+			return xerrors.Errorf("must pass account and address")
+		}
+
+		addr, err := address.NewFromString(ctx.Args().Get(1))
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.UnbindAddress(ctx.Context, ctx.Args().First(), addr); err != nil {
+			return err
+		}
+		fmt.Println("unbind address success!")
+
+		return nil
+	},
+}
+
+var listAddrBindingCmd = &cli.Command{
+	Name:  "list-binding",
+	Usage: "list the bindings of account and address",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "only list the bindings of account",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		bindings, err := client.ListAddressBinding(ctx.Context, ctx.String("account"))
+		if err != nil {
+			return err
+		}
+
+		bytes, err := json.MarshalIndent(bindings, " ", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	},
+}
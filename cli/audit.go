@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+var AuditCmds = &cli.Command{
+	Name:  "audit",
+	Usage: "the records of calls which need admin permission",
+	Subcommands: []*cli.Command{
+		listAuditCmd,
+	},
+}
+
+var listAuditCmd = &cli.Command{
+	Name:  "list",
+	Usage: "list audit records, the latest first",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "only list the calls of account",
+		},
+		&cli.StringFlag{
+			Name:  "method",
+			Usage: "only list the calls of method, eg. ResetAddress",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "max number of records, 0 means no limit",
+			Value: 100,
+		},
+		reportOutputFlag,
+	}, timeRangeFlags...),
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		params := &types.AuditListParams{
+			Account: ctx.String("account"),
+			Method:  ctx.String("method"),
+			Limit:   ctx.Int("limit"),
+		}
+		if params.Start, params.End, err = parseTimeRange(ctx); err != nil {
+			return err
+		}
+
+		records, err := client.ListAuditRecord(ctx.Context, params)
+		if err != nil {
+			return err
+		}
+
+		header := []string{"Time", "Account", "IP", "Method", "Args", "Result", "Error"}
+		rows := make([][]string, 0, len(records))
+		for _, record := range records {
+			rows = append(rows, []string{
+				record.CreatedAt.Format(timeLayout),
+				record.Account,
+				record.IP,
+				record.Method,
+				record.Args,
+				record.Result,
+				record.Error,
+			})
+		}
+
+		return outputReport(ctx.String("output-type"), records, header, rows)
+	},
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/cli/tablewriter"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+var AuthCmds = &cli.Command{
+	Name:  "auth",
+	Usage: "manage the local tokens signed by messager",
+	Subcommands: []*cli.Command{
+		createTokenCmd,
+		revokeTokenCmd,
+		listTokenCmd,
+	},
+}
+
+var createTokenCmd = &cli.Command{
+	Name:  "create-token",
+	Usage: "create a token signed by the local secret",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "token name, it is used as the account of caller",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "perm",
+			Usage: "permission of token, one of read, write, sign, admin",
+			Value: "read",
+		},
+		&cli.DurationFlag{
+			Name:  "expire",
+			Usage: "token expires after the duration, eg. 24h, 0 means never expire",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		token, err := client.CreateToken(ctx.Context, &types.CreateTokenParams{
+			Name:   ctx.String("name"),
+			Perm:   ctx.String("perm"),
+			Expire: ctx.Duration("expire"),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println(token)
+
+		return nil
+	},
+}
+
+var revokeTokenCmd = &cli.Command{
+	Name:      "revoke",
+	Usage:     "revoke a token created by create-token",
+	ArgsUsage: "id",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass id")
+		}
+		id, err := types.ParseUUID(ctx.Args().First())
+		if err != nil || id.IsEmpty() {
+			return xerrors.Errorf("invalid id %s", ctx.Args().First())
+		}
+
+		if _, err := client.RevokeToken(ctx.Context, id); err != nil {
+			return err
+		}
+		fmt.Println("revoke token success!")
+
+		return nil
+	},
+}
+
+var listTokenCmd = &cli.Command{
+	Name:  "list",
+	Usage: "list tokens created by create-token",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		tokens, err := client.ListToken(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		tokenTw := tablewriter.New(
+			tablewriter.Col("ID"),
+			tablewriter.Col("Name"),
+			tablewriter.Col("Perm"),
+			tablewriter.Col("ExpireAt"),
+			tablewriter.Col("State"),
+			tablewriter.Col("CreatedAt"),
+		)
+		for _, token := range tokens {
+			expireAt := "never"
+			state := "valid"
+			if !token.ExpireAt.IsZero() {
+				expireAt = token.ExpireAt.Format(timeLayout)
+				if !time.Now().Before(token.ExpireAt) {
+					state = "expired"
+				}
+			}
+			if token.Revoked {
+				state = "revoked"
+			}
+			tokenTw.Write(map[string]interface{}{
+				"ID":        token.ID,
+				"Name":      token.Name,
+				"Perm":      token.Perm,
+				"ExpireAt":  expireAt,
+				"State":     state,
+				"CreatedAt": token.CreatedAt.Format(timeLayout),
+			})
+		}
+
+		buf := new(bytes.Buffer)
+		if err := tokenTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/urfave/cli/v2"
+
+	"github.com/filecoin-project/venus-messager/cli/tablewriter"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+var ChainCmds = &cli.Command{
+	Name:  "chain",
+	Usage: "chain commands",
+	Subcommands: []*cli.Command{
+		feesCmd,
+	},
+}
+
+var feesCmd = &cli.Command{
+	Name:  "fees",
+	Usage: "show the history of base fee and the premium paid by our messages",
+	Flags: append([]cli.Flag{
+		outputTypeFlag,
+	}, timeRangeFlags...),
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		start, end, err := parseTimeRange(ctx)
+		if err != nil {
+			return err
+		}
+
+		histories, err := client.ListFeeHistory(ctx.Context, start, end)
+		if err != nil {
+			return err
+		}
+
+		if ctx.String("output-type") != "table" {
+			bytes, err := json.MarshalIndent(histories, " ", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+			return nil
+		}
+
+		return outputFeeHistory(histories)
+	},
+}
+
+func outputFeeHistory(histories []*types.FeeHistory) error {
+	feeTw := tablewriter.New(
+		tablewriter.Col("Height"),
+		tablewriter.Col("Time"),
+		tablewriter.Col("BaseFee"),
+		tablewriter.Col("MedianPremium"),
+		tablewriter.Col("MsgCount"),
+		tablewriter.Col("GasLimit"),
+		tablewriter.Col("PremiumPaid"),
+	)
+
+	var baseFees, medianPremiums []big.Int
+	gasLimit := int64(0)
+	premiumPaid := big.Zero()
+	for _, history := range histories {
+		feeTw.Write(map[string]interface{}{
+			"Height":        history.Height,
+			"Time":          time.Unix(int64(history.Timestamp), 0).Format(timeLayout),
+			"BaseFee":       history.BaseFee,
+			"MedianPremium": history.MedianPremium,
+			"MsgCount":      history.MsgCount,
+			"GasLimit":      history.GasLimit,
+			"PremiumPaid":   history.PremiumPaid,
+		})
+		baseFees = append(baseFees, history.BaseFee)
+		medianPremiums = append(medianPremiums, history.MedianPremium)
+		gasLimit += history.GasLimit
+		premiumPaid = big.Add(premiumPaid, history.PremiumPaid)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := feeTw.Flush(buf); err != nil {
+		return err
+	}
+	fmt.Println(buf)
+
+	avgPremium := big.Zero()
+	if gasLimit > 0 {
+		avgPremium = big.Div(premiumPaid, big.NewInt(gasLimit))
+	}
+	fmt.Printf("tipset count: %d, median base fee: %s, median premium: %s, our average premium: %s, our total premium: %s\n",
+		len(histories), median(baseFees), median(medianPremiums), avgPremium, premiumPaid)
+
+	return nil
+}
+
+func median(vals []big.Int) big.Int {
+	if len(vals) == 0 {
+		return big.Zero()
+	}
+	sort.Slice(vals, func(i, j int) bool {
+		return vals[i].LessThan(vals[j])
+	})
+	return vals[len(vals)/2]
+}
@@ -2,10 +2,12 @@ package cli
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -34,12 +36,17 @@ var MsgCmds = &cli.Command{
 		listCmd,
 		listFailedCmd,
 		ListBlockedMessageCmd,
+		baseFeeGateCmd,
+		queueDepthCmd,
+		listExternalCmd,
 		updateFilledMessageCmd,
 		updateAllFilledMessageCmd,
 		replaceCmd,
 		waitMessagerCmd,
 		republishCmd,
+		pushSignedCmd,
 		markBadCmd,
+		unblockCmd,
 	},
 }
 
@@ -99,11 +106,43 @@ var searchCmd = &cli.Command{
 			return xerrors.Errorf("value of query must be entered")
 		}
 
-		bytes, err := json.MarshalIndent(transformMessage(msg), " ", "\t")
+		data, err := json.MarshalIndent(transformMessage(msg), " ", "\t")
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(bytes))
+		fmt.Println(string(data))
+
+		if msg.SignedCid == nil {
+			return nil
+		}
+		results, err := client.ListBroadcastResult(ctx.Context, *msg.SignedCid)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("not broadcast yet")
+			return nil
+		}
+		resultTw := tablewriter.New(
+			tablewriter.Col("Node"),
+			tablewriter.Col("Accepted"),
+			tablewriter.Col("Error"),
+			tablewriter.Col("UpdatedAt"),
+		)
+		for _, r := range results {
+			resultTw.Write(map[string]interface{}{
+				"Node":      r.Node,
+				"Accepted":  r.Accepted,
+				"Error":     r.Error,
+				"UpdatedAt": r.UpdatedAt.Format(timeLayout),
+			})
+		}
+		buf := new(bytes.Buffer)
+		if err := resultTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println("broadcast status:")
+		fmt.Println(buf)
 		return nil
 	},
 }
@@ -166,6 +205,7 @@ state:
   4:  FailedMsg
   5:  ReplacedMsg
   6:  NoWalletMsg
+  7:  BlockedByPolicyMsg
 `,
 		},
 	},
@@ -316,6 +356,179 @@ var ListBlockedMessageCmd = &cli.Command{
 	},
 }
 
+var baseFeeGateCmd = &cli.Command{
+	Name:  "base-fee-gate",
+	Usage: "show the current base fee and the messages held by base fee threshold",
+	Flags: []cli.Flag{
+		outputTypeFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		gate, err := client.GetBaseFeeGate(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		if ctx.String("output-type") != "table" {
+			bytes, err := json.MarshalIndent(gate, " ", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+			return nil
+		}
+
+		fmt.Printf("height: %d, base fee: %s, global threshold: %s\n", gate.Height, gate.BaseFee, gate.Threshold)
+		gateTw := tablewriter.New(
+			tablewriter.Col("Address"),
+			tablewriter.Col("Threshold"),
+			tablewriter.Col("Gated"),
+			tablewriter.Col("HeldCount"),
+		)
+		for _, addr := range gate.Addresses {
+			gateTw.Write(map[string]interface{}{
+				"Address":   addr.Addr,
+				"Threshold": addr.Threshold,
+				"Gated":     addr.Gated,
+				"HeldCount": addr.HeldCount,
+			})
+		}
+		buf := new(bytes.Buffer)
+		if err := gateTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
+
+var listExternalCmd = &cli.Command{
+	Name:  "list-external",
+	Usage: "list messages sent from managed addresses out of messager",
+	Flags: []cli.Flag{
+		FromFlag,
+		outputTypeFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		from := address.Undef
+		if str := ctx.String("from"); len(str) > 0 {
+			from, err = address.NewFromString(str)
+			if err != nil {
+				return err
+			}
+		}
+
+		msgs, err := client.ListExternalMessage(ctx.Context, from)
+		if err != nil {
+			return err
+		}
+
+		if ctx.String("output-type") != "table" {
+			bytes, err := json.MarshalIndent(msgs, " ", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+			return nil
+		}
+
+		extTw := tablewriter.New(
+			tablewriter.Col("Cid"),
+			tablewriter.Col("From"),
+			tablewriter.Col("Nonce"),
+			tablewriter.Col("Height"),
+			tablewriter.Col("ExitCode"),
+			tablewriter.Col("ConflictMsgID"),
+			tablewriter.Col("CreatedAt"),
+		)
+		for _, msg := range msgs {
+			exitCode := "-"
+			if msg.Receipt != nil {
+				exitCode = msg.Receipt.ExitCode.String()
+			}
+			extTw.Write(map[string]interface{}{
+				"Cid":           msg.Cid,
+				"From":          msg.From,
+				"Nonce":         msg.Nonce,
+				"Height":        msg.Height,
+				"ExitCode":      exitCode,
+				"ConflictMsgID": msg.ConflictMsgID,
+				"CreatedAt":     msg.CreatedAt.Format(timeLayout),
+			})
+		}
+		buf := new(bytes.Buffer)
+		if err := extTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
+
+var queueDepthCmd = &cli.Command{
+	Name:  "queue-depth",
+	Usage: "show the number of unfilled and filled messages of each address and the high-water marks",
+	Flags: []cli.Flag{
+		outputTypeFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		depth, err := client.GetQueueDepth(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		if ctx.String("output-type") != "table" {
+			bytes, err := json.MarshalIndent(depth, " ", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+			return nil
+		}
+
+		fmt.Printf("unfilled: %d, filled: %d, address high-water mark: %d, total high-water mark: %d\n",
+			depth.UnFilled, depth.Filled, depth.AddrHighWaterMark, depth.TotalHighWaterMark)
+		depthTw := tablewriter.New(
+			tablewriter.Col("Address"),
+			tablewriter.Col("UnFilled"),
+			tablewriter.Col("Filled"),
+		)
+		for _, addr := range depth.Addrs {
+			depthTw.Write(map[string]interface{}{
+				"Address":  addr.Addr,
+				"UnFilled": addr.UnFilled,
+				"Filled":   addr.Filled,
+			})
+		}
+		buf := new(bytes.Buffer)
+		if err := depthTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+
+		return nil
+	},
+}
+
 var tw = tablewriter.New(
 	tablewriter.Col("ID"),
 	tablewriter.Col("To"),
@@ -546,6 +759,70 @@ var republishCmd = &cli.Command{
 	},
 }
 
+var pushSignedCmd = &cli.Command{
+	Name:      "push-signed",
+	Usage:     "import a message signed out of messager, and push it to node",
+	ArgsUsage: "<signed message in json or hex encoded cbor>",
+	Action: func(cctx *cli.Context) error {
+		client, closer, err := getAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if cctx.NArg() == 0 {
+			return xerrors.New("must has signed message argument")
+		}
+
+		arg := strings.TrimSpace(cctx.Args().Get(0))
+		var msg venusTypes.SignedMessage
+		if strings.HasPrefix(arg, "{") {
+			err = json.Unmarshal([]byte(arg), &msg)
+		} else {
+			var data []byte
+			data, err = hex.DecodeString(arg)
+			if err == nil {
+				err = msg.UnmarshalCBOR(bytes.NewReader(data))
+			}
+		}
+		if err != nil {
+			return xerrors.Errorf("decode signed message failed %v", err)
+		}
+
+		id, err := client.PushSignedMessage(cctx.Context, &msg, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Println(id)
+		return nil
+	},
+}
+
+var unblockCmd = &cli.Command{
+	Name:      "unblock",
+	Usage:     "unblock messages blocked by sign policy, they will be checked against the policies again when selected",
+	ArgsUsage: "id slice",
+	Action: func(cctx *cli.Context) error {
+		client, closer, err := getAPI(cctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if cctx.NArg() == 0 {
+			return xerrors.New("must has id argument")
+		}
+		for _, id := range cctx.Args().Slice() {
+			if _, err := client.UnblockMessage(cctx.Context, id); err != nil {
+				fmt.Printf("unblock msg %s fail %v\n", id, err)
+				continue
+			}
+		}
+
+		return nil
+	},
+}
+
 var markBadCmd = &cli.Command{
 	Name:  "mark-bad",
 	Usage: "mark bad message",
@@ -617,6 +894,7 @@ type message struct {
 	Confidence int64
 	Receipt    *receipt
 	TipSetKey  venusTypes.TipSetKey
+	Fee        *types.MsgFee
 
 	Meta *types.MsgMeta
 
@@ -649,6 +927,7 @@ func transformMessage(msg *types.Message) *message {
 		Height:          msg.Height,
 		Confidence:      msg.Confidence,
 		TipSetKey:       msg.TipSetKey,
+		Fee:             msg.Fee,
 		Meta:            msg.Meta,
 		WalletName:      msg.WalletName,
 		FromUser:        msg.FromUser,
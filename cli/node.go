@@ -37,6 +37,11 @@ var addNodeCmd = &cli.Command{
 			Name:  "token",
 			Usage: "node token",
 		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "node type, full node also serves estimation, state queries and head events when the configured node fails, light node only broadcast messages (full, light)",
+			Value: "light",
+		},
 	},
 	Action: func(ctx *cli.Context) error {
 		client, closer, err := getAPI(ctx)
@@ -56,6 +61,10 @@ var addNodeCmd = &cli.Command{
 		if len(node.Token) == 0 {
 			return xerrors.Errorf("token cannot be empty")
 		}
+		node.Type, err = types.ParseNodeType(ctx.String("type"))
+		if err != nil {
+			return err
+		}
 
 		has, err := client.HasNode(ctx.Context, node.Name)
 		if err != nil {
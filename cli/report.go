@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/cli/tablewriter"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+var ReportCmds = &cli.Command{
+	Name:  "report",
+	Usage: "usage and fee reports",
+	Subcommands: []*cli.Command{
+		usageReportCmd,
+		feeReportCmd,
+	},
+}
+
+var timeRangeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "since",
+		Usage: "only include data in the recent period of time, eg. 3h, 168h",
+		Value: "24h",
+	},
+	&cli.StringFlag{
+		Name:  "start",
+		Usage: "start time, eg. '2021-08-01 00:00:00', override since",
+	},
+	&cli.StringFlag{
+		Name:  "end",
+		Usage: "end time, eg. '2021-08-08 00:00:00' (default now)",
+	},
+}
+
+var reportOutputFlag = &cli.StringFlag{
+	Name:  "output-type",
+	Usage: "output type support table, csv and json",
+	Value: "table",
+}
+
+func parseTimeRange(ctx *cli.Context) (time.Time, time.Time, error) {
+	var start time.Time
+	end := time.Now()
+	var err error
+	if ctx.IsSet("end") {
+		if end, err = time.ParseInLocation(timeLayout, ctx.String("end"), time.Local); err != nil {
+			return start, end, xerrors.Errorf("parsing end: %v", err)
+		}
+	}
+	if ctx.IsSet("start") {
+		if start, err = time.ParseInLocation(timeLayout, ctx.String("start"), time.Local); err != nil {
+			return start, end, xerrors.Errorf("parsing start: %v", err)
+		}
+		return start, end, nil
+	}
+	since, err := time.ParseDuration(ctx.String("since"))
+	if err != nil {
+		return start, end, xerrors.Errorf("parsing since: %v", err)
+	}
+
+	return end.Add(-since), end, nil
+}
+
+var usageReportCmd = &cli.Command{
+	Name:  "usage",
+	Usage: "message counts, success rate, gas used and fee paid of accounts",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "only report the account, default all accounts",
+		},
+		reportOutputFlag,
+	}, timeRangeFlags...),
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		params := &types.UsageReportParams{Account: ctx.String("account")}
+		if params.Start, params.End, err = parseTimeRange(ctx); err != nil {
+			return err
+		}
+
+		usages, err := client.GetUsageReport(ctx.Context, params)
+		if err != nil {
+			return err
+		}
+
+		header := []string{"Account", "MsgCount", "SuccessCount", "FailedCount", "PendingCount", "SuccessRate",
+			"FailureRate", "GasUsed", "BaseFeeBurn", "OverEstimationBurn", "MinerTip", "TotalFee"}
+		rows := make([][]string, 0, len(usages))
+		for _, usage := range usages {
+			rows = append(rows, []string{
+				usage.Account,
+				strconv.Itoa(usage.MsgCount),
+				strconv.Itoa(usage.SuccessCount),
+				strconv.Itoa(usage.FailedCount),
+				strconv.Itoa(usage.PendingCount),
+				strconv.FormatFloat(usage.SuccessRate, 'f', 4, 64),
+				strconv.FormatFloat(usage.FailureRate, 'f', 4, 64),
+				strconv.FormatInt(usage.GasUsed, 10),
+				usage.BaseFeeBurn.String(),
+				usage.OverEstimationBurn.String(),
+				usage.MinerTip.String(),
+				usage.TotalFee.String(),
+			})
+		}
+
+		return outputReport(ctx.String("output-type"), usages, header, rows)
+	},
+}
+
+var feeReportCmd = &cli.Command{
+	Name:  "fee",
+	Usage: "fee paid by on chain messages group by address, user or method",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "group-by",
+			Usage: "one of address, user and method",
+			Value: types.FeeReportByAddress,
+		},
+		reportOutputFlag,
+	}, timeRangeFlags...),
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		params := &types.FeeReportParams{GroupBy: ctx.String("group-by")}
+		if params.Start, params.End, err = parseTimeRange(ctx); err != nil {
+			return err
+		}
+
+		reports, err := client.GetFeeReport(ctx.Context, params)
+		if err != nil {
+			return err
+		}
+
+		header := []string{"Key", "MsgCount", "GasUsed", "BaseFeeBurn", "OverEstimationBurn", "MinerTip", "TotalFee"}
+		rows := make([][]string, 0, len(reports))
+		for _, report := range reports {
+			rows = append(rows, []string{
+				report.Key,
+				strconv.Itoa(report.MsgCount),
+				strconv.FormatInt(report.GasUsed, 10),
+				report.BaseFeeBurn.String(),
+				report.OverEstimationBurn.String(),
+				report.MinerTip.String(),
+				report.TotalFee.String(),
+			})
+		}
+
+		return outputReport(ctx.String("output-type"), reports, header, rows)
+	},
+}
+
+func outputReport(outputType string, report interface{}, header []string, rows [][]string) error {
+	switch outputType {
+	case "json":
+		bytes, err := json.MarshalIndent(report, " ", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+	case "table":
+		cols := make([]tablewriter.Column, 0, len(header))
+		for _, name := range header {
+			cols = append(cols, tablewriter.Col(name))
+		}
+		reportTw := tablewriter.New(cols...)
+		for _, row := range rows {
+			line := make(map[string]interface{}, len(header))
+			for i, name := range header {
+				line[name] = row[i]
+			}
+			reportTw.Write(line)
+		}
+		buf := new(bytes.Buffer)
+		if err := reportTw.Flush(buf); err != nil {
+			return err
+		}
+		fmt.Println(buf)
+	default:
+		return xerrors.Errorf("unexpected output type %s", outputType)
+	}
+
+	return nil
+}
@@ -51,6 +51,10 @@ var SendCmd = &cli.Command{
 			Name:  "params-hex",
 			Usage: "specify invocation parameters in hex",
 		},
+		&cli.IntFlag{
+			Name:  "priority",
+			Usage: "message priority, -1: low, 0: normal, 1: high, 2: urgent. high and urgent messages are not held by base fee threshold",
+		},
 		&cli.StringFlag{
 			Name:     "account",
 			Usage:    "optionally specify the account to send",
@@ -111,6 +115,7 @@ var SendCmd = &cli.Command{
 		}
 
 		params.Method = abi.MethodNum(ctx.Uint64("method"))
+		params.Priority = types.MsgPriority(ctx.Int("priority"))
 
 		if ctx.IsSet("params-json") {
 			params.Params = ctx.String("params-json")
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+var SignPolicyCmds = &cli.Command{
+	Name:  "sign-policy",
+	Usage: "the policies checked before signing messages",
+	Subcommands: []*cli.Command{
+		addSignPolicyCmd,
+		updateSignPolicyCmd,
+		listSignPolicyCmd,
+		deleteSignPolicyCmd,
+	},
+}
+
+var signPolicyFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "address",
+		Usage: "the policy applies to messages from the address, empty means all addresses",
+	},
+	&cli.StringFlag{
+		Name:  "account",
+		Usage: "the policy applies to messages pushed by the account, empty means all accounts",
+	},
+	&cli.StringSliceFlag{
+		Name:  "allowed-to",
+		Usage: "allowed receivers, eg. --allowed-to f01000 --allowed-to f01001",
+	},
+	&cli.StringSliceFlag{
+		Name:  "allowed-methods",
+		Usage: "allowed methods of actor, eg. --allowed-methods storageminer:PreCommitSector,ProveCommitSector --allowed-methods account",
+	},
+	&cli.StringFlag{
+		Name:  "max-value",
+		Usage: "max value of one message (attoFIL), 0 means no limit",
+	},
+	&cli.StringFlag{
+		Name:  "max-daily-value",
+		Usage: "max total value of messages signed in 24 hours (attoFIL), 0 means no limit",
+	},
+	&cli.StringFlag{
+		Name:  "max-fee",
+		Usage: "max fee of one message (attoFIL), 0 means no limit",
+	},
+}
+
+var addSignPolicyCmd = &cli.Command{
+	Name:  "add",
+	Usage: "add a sign policy",
+	Flags: signPolicyFlags,
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		policy := &types.SignPolicy{Addr: address.Undef}
+		if err := parseSignPolicyFlags(ctx, policy); err != nil {
+			return err
+		}
+
+		id, err := client.SaveSignPolicy(ctx.Context, policy)
+		if err != nil {
+			return err
+		}
+		fmt.Println("add sign policy", id)
+
+		return nil
+	},
+}
+
+var updateSignPolicyCmd = &cli.Command{
+	Name:      "update",
+	Usage:     "update a sign policy, only the flags set are changed",
+	ArgsUsage: "id",
+	Flags:     signPolicyFlags,
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass id")
+		}
+		id, err := types.ParseUUID(ctx.Args().First())
+		if err != nil || id.IsEmpty() {
+			return xerrors.Errorf("invalid id %s", ctx.Args().First())
+		}
+
+		policies, err := client.ListSignPolicy(ctx.Context)
+		if err != nil {
+			return err
+		}
+		var policy *types.SignPolicy
+		for _, p := range policies {
+			if p.ID == id {
+				policy = p
+				break
+			}
+		}
+		if policy == nil {
+			return xerrors.Errorf("sign policy %s not found", id)
+		}
+		if err := parseSignPolicyFlags(ctx, policy); err != nil {
+			return err
+		}
+
+		_, err = client.SaveSignPolicy(ctx.Context, policy)
+
+		return err
+	},
+}
+
+var listSignPolicyCmd = &cli.Command{
+	Name:  "list",
+	Usage: "list sign policies",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		policies, err := client.ListSignPolicy(ctx.Context)
+		if err != nil {
+			return err
+		}
+
+		bytes, err := json.MarshalIndent(policies, " ", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	},
+}
+
+var deleteSignPolicyCmd = &cli.Command{
+	Name:      "del",
+	Usage:     "delete a sign policy",
+	ArgsUsage: "id",
+	Action: func(ctx *cli.Context) error {
+		client, closer, err := getAPI(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if !ctx.Args().Present() {
+			return xerrors.Errorf("must pass id")
+		}
+		id, err := types.ParseUUID(ctx.Args().First())
+		if err != nil || id.IsEmpty() {
+			return xerrors.Errorf("invalid id %s", ctx.Args().First())
+		}
+
+		_, err = client.DeleteSignPolicy(ctx.Context, id)
+
+		return err
+	},
+}
+
+func parseSignPolicyFlags(ctx *cli.Context, policy *types.SignPolicy) error {
+	var err error
+	if ctx.IsSet("address") {
+		policy.Addr = address.Undef
+		if len(ctx.String("address")) > 0 {
+			if policy.Addr, err = address.NewFromString(ctx.String("address")); err != nil {
+				return err
+			}
+		}
+	}
+	if ctx.IsSet("account") {
+		policy.Account = ctx.String("account")
+	}
+	if ctx.IsSet("allowed-to") {
+		policy.AllowedTo = nil
+		for _, str := range ctx.StringSlice("allowed-to") {
+			to, err := address.NewFromString(str)
+			if err != nil {
+				return err
+			}
+			policy.AllowedTo = append(policy.AllowedTo, to)
+		}
+	}
+	if ctx.IsSet("allowed-methods") {
+		policy.AllowedMethods = make(map[string][]string)
+		for _, str := range ctx.StringSlice("allowed-methods") {
+			actorMethods := strings.SplitN(str, ":", 2)
+			var methods []string
+			if len(actorMethods) == 2 && len(actorMethods[1]) > 0 {
+				methods = strings.Split(actorMethods[1], ",")
+			}
+			policy.AllowedMethods[actorMethods[0]] = methods
+		}
+	}
+	if ctx.IsSet("max-value") {
+		if policy.MaxValue, err = venusTypes.BigFromString(ctx.String("max-value")); err != nil {
+			return xerrors.Errorf("parsing max-value: %v", err)
+		}
+	}
+	if ctx.IsSet("max-daily-value") {
+		if policy.MaxDailyValue, err = venusTypes.BigFromString(ctx.String("max-daily-value")); err != nil {
+			return xerrors.Errorf("parsing max-daily-value: %v", err)
+		}
+	}
+	if ctx.IsSet("max-fee") {
+		if policy.MaxFee, err = venusTypes.BigFromString(ctx.String("max-fee")); err != nil {
+			return xerrors.Errorf("parsing max-fee: %v", err)
+		}
+	}
+
+	return nil
+}
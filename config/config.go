@@ -30,6 +30,8 @@ type LogConfig struct {
 
 type APIConfig struct {
 	Address string
+	// non-admin callers can only access the messages and addresses of their own account
+	AccountScoped bool `toml:"accountScoped"`
 }
 
 type DbConfig struct {
@@ -67,6 +69,36 @@ type MessageServiceConfig struct {
 	TipsetFilePath  string `toml:"tipsetFilePath"`
 	SkipProcessHead bool   `toml:"skipProcessHead"`
 	SkipPushMessage bool   `toml:"skipPushMessage"`
+	// only allow accounts to push messages from addresses bound to them
+	RequireAddressBinding bool `toml:"requireAddressBinding"`
+
+	// when unfilled messages of an address or in total reach the high-water mark, pushes are
+	// rejected or accepted with low priority according to the policy, 0 means no limit
+	AddrHighWaterMark  uint64 `toml:"addrHighWaterMark"`
+	TotalHighWaterMark uint64 `toml:"totalHighWaterMark"`
+	// reject or defer
+	BackpressurePolicy string `toml:"backpressurePolicy"`
+
+	// number of nodes asked to estimate gas, the configured node and healthy full nodes in database,
+	// 0 or 1 means only the configured node
+	EstimateNodeNum int `toml:"estimateNodeNum"`
+	// min number of results left after outliers dropped, otherwise the estimation of message fails
+	EstimateQuorum int `toml:"estimateQuorum"`
+	// rule to combine gas premium and fee cap of nodes, median or max
+	EstimatePremiumRule string `toml:"estimatePremiumRule"`
+	// rule to combine gas limit of nodes, median or max
+	EstimateGasLimitRule string `toml:"estimateGasLimitRule"`
+	// results deviate from the median more than the ratio are dropped as outliers, 0 means keep all
+	EstimateOutlierRatio float64 `toml:"estimateOutlierRatio"`
+
+	// interval in seconds to check filled messages are still in mpool, missing messages are republished,
+	// 0 means no check and filled messages are pushed again every round
+	MpoolWatchInterval int `toml:"mpoolWatchInterval"`
+
+	// number of healthy full nodes in database subscribed to head changes besides the configured node,
+	// events are deduplicated by tipset key and the earliest one is processed, events of extra nodes are only processed
+	// when they extend the current head, 0 means only the configured node
+	ExtraHeadSources int `toml:"extraHeadSources"`
 }
 
 type MessageStateConfig struct {
@@ -121,6 +153,21 @@ func DefaultConfig() *Config {
 			TipsetFilePath:  "./tipset.json",
 			SkipProcessHead: false,
 			SkipPushMessage: false,
+
+			RequireAddressBinding: false,
+
+			AddrHighWaterMark:  0,
+			TotalHighWaterMark: 0,
+			BackpressurePolicy: "reject",
+
+			EstimateNodeNum:      1,
+			EstimateQuorum:       1,
+			EstimatePremiumRule:  "median",
+			EstimateGasLimitRule: "max",
+			EstimateOutlierRatio: 0.5,
+
+			MpoolWatchInterval: 0,
+			ExtraHeadSources:   0,
 		},
 		Gateway: GatewayConfig{
 			RemoteEnable: false,
@@ -24,6 +24,7 @@ require (
 	github.com/ipfs-force-community/venus-common-utils v0.0.0-20210714051450-5b18e20bb913
 	github.com/ipfs-force-community/venus-gateway v0.0.0-20210528060921-460ec6185a7d
 	github.com/ipfs/go-cid v0.0.7
+	github.com/kilic/bls12-381 v0.0.0-20200820230200-6b2c19996391
 	github.com/multiformats/go-multiaddr v0.3.3
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/pelletier/go-toml v1.6.0
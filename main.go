@@ -42,6 +42,12 @@ func main() {
 			ccli.AddrCmds,
 			ccli.SharedParamsCmds,
 			ccli.NodeCmds,
+			ccli.ChainCmds,
+			ccli.ReportCmds,
+			ccli.SignPolicyCmds,
+			ccli.AuthCmds,
+			ccli.AuditCmds,
+			ccli.AccountCmds,
 			ccli.LogCmds,
 			ccli.SendCmd,
 			runCmd,
@@ -164,11 +170,11 @@ func runAction(ctx *cli.Context) error {
 	log.Infof("auth info url: %s", cfg.JWT.AuthURL)
 	log.Infof("gateway info enable: %v, url: %s, token: %s", cfg.Gateway.RemoteEnable, cfg.Gateway.Url, cfg.Node.Token)
 
-	client, closer, err := service.NewNodeClient(ctx.Context, &cfg.Node)
+	nodeFailover, err := service.NewNodeFailover(ctx.Context, &cfg.Node, log)
 	if err != nil {
 		return xerrors.Errorf("connect to node failed %v", err)
 	}
-	defer closer()
+	defer nodeFailover.Close()
 
 	mAddr, err := ma.NewMultiaddr(cfg.API.Address)
 	if err != nil {
@@ -204,7 +210,7 @@ func runAction(ctx *cli.Context) error {
 		//prover
 		fx.Supply(cfg, &cfg.DB, &cfg.API, &cfg.JWT, &cfg.Node, &cfg.Log, &cfg.MessageService, &cfg.MessageState, &cfg.Wallet, &cfg.Gateway),
 		fx.Supply(log),
-		fx.Supply(client),
+		fx.Supply(nodeFailover, nodeFailover.Client()),
 		fx.Supply(walletClient),
 		fx.Supply((ShutdownChan)(shutdownChan)),
 
@@ -227,7 +233,9 @@ func runAction(ctx *cli.Context) error {
 	invoker := fx.Options(
 		//invoke
 		fx.Invoke(models.AutoMigrate),
+		fx.Invoke(service.StartNodeFailover),
 		fx.Invoke(service.StartNodeEvents),
+		fx.Invoke(service.StartNodeHealthMonitor),
 		fx.Invoke(api.RunAPI),
 	)
 	app := fx.New(gatewayProvider, provider, invoker)
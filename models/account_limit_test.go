@@ -0,0 +1,109 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestAccountLimit(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	accountLimitRepoTest := func(t *testing.T, limitRepo repo.AccountLimitRepo) {
+		ctx := context.Background()
+		account := types.NewUUID().String()
+
+		_, err := limitRepo.GetAccountLimit(ctx, account)
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+		limit := &types.AccountLimit{
+			Account:        account,
+			PushPerMinute:  10,
+			MaxPendingMsg:  100,
+			MaxParamsBytes: 1024,
+		}
+		assert.NoError(t, limitRepo.SaveAccountLimit(ctx, limit))
+
+		r, err := limitRepo.GetAccountLimit(ctx, account)
+		assert.NoError(t, err)
+		assert.Equal(t, limit.PushPerMinute, r.PushPerMinute)
+		assert.Equal(t, limit.MaxPendingMsg, r.MaxPendingMsg)
+		assert.Equal(t, limit.MaxParamsBytes, r.MaxParamsBytes)
+
+		// save again replace the limit and keep the create time
+		assert.NoError(t, limitRepo.SaveAccountLimit(ctx, &types.AccountLimit{Account: account, PushPerMinute: 5}))
+		r2, err := limitRepo.GetAccountLimit(ctx, account)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), r2.PushPerMinute)
+		assert.Equal(t, int64(0), r2.MaxPendingMsg)
+		assert.Equal(t, r.CreatedAt.Unix(), r2.CreatedAt.Unix())
+
+		list, err := limitRepo.ListAccountLimit(ctx)
+		assert.NoError(t, err)
+		found := false
+		for _, l := range list {
+			if l.Account == account {
+				found = true
+			}
+		}
+		assert.True(t, found)
+
+		assert.NoError(t, limitRepo.DelAccountLimit(ctx, account))
+		_, err = limitRepo.GetAccountLimit(ctx, account)
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		accountLimitRepoTest(t, sqliteRepo.AccountLimitRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		accountLimitRepoTest(t, mysqlRepo.AccountLimitRepo())
+	})
+}
+
+func TestAccountWeight(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	accountWeightRepoTest := func(t *testing.T, weightRepo repo.AccountWeightRepo) {
+		ctx := context.Background()
+		account := types.NewUUID().String()
+
+		findWeight := func() *types.AccountWeight {
+			list, err := weightRepo.ListAccountWeight(ctx)
+			assert.NoError(t, err)
+			for _, w := range list {
+				if w.Account == account {
+					return w
+				}
+			}
+			return nil
+		}
+
+		assert.NoError(t, weightRepo.SaveAccountWeight(ctx, &types.AccountWeight{Account: account, Weight: 3}))
+		w := findWeight()
+		assert.NotNil(t, w)
+		assert.Equal(t, int64(3), w.Weight)
+
+		assert.NoError(t, weightRepo.SaveAccountWeight(ctx, &types.AccountWeight{Account: account, Weight: 5}))
+		assert.Equal(t, int64(5), findWeight().Weight)
+
+		assert.NoError(t, weightRepo.DelAccountWeight(ctx, account))
+		assert.Nil(t, findWeight())
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		accountWeightRepoTest(t, sqliteRepo.AccountWeightRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		accountWeightRepoTest(t, mysqlRepo.AccountWeightRepo())
+	})
+}
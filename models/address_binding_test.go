@@ -0,0 +1,70 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestAddressBinding(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	addressBindingRepoTest := func(t *testing.T, bindingRepo repo.AddressBindingRepo) {
+		ctx := context.Background()
+		account := types.NewUUID().String()
+		addr, err := address.NewIDAddress(uint64(types.NewUUID()[0]) + 1000)
+		assert.NoError(t, err)
+
+		has, err := bindingRepo.HasAddressBinding(ctx, account, addr)
+		assert.NoError(t, err)
+		assert.False(t, has)
+
+		binding := &types.AddressBinding{
+			ID:      types.NewUUID(),
+			Account: account,
+			Addr:    addr,
+			Methods: []abi.MethodNum{2, 3},
+		}
+		assert.NoError(t, bindingRepo.SaveAddressBinding(ctx, binding))
+
+		r, err := bindingRepo.GetAddressBinding(ctx, account, addr)
+		assert.NoError(t, err)
+		assert.Equal(t, binding.ID, r.ID)
+		assert.Equal(t, binding.Methods, r.Methods)
+		assert.True(t, r.AllowMethod(2))
+		assert.False(t, r.AllowMethod(4))
+
+		// rebind replace the methods and keep the id
+		assert.NoError(t, bindingRepo.SaveAddressBinding(ctx, &types.AddressBinding{
+			ID:      types.NewUUID(),
+			Account: account,
+			Addr:    addr,
+		}))
+		list, err := bindingRepo.ListAddressBinding(ctx, account)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, binding.ID, list[0].ID)
+		assert.Len(t, list[0].Methods, 0)
+		assert.True(t, list[0].AllowMethod(4))
+
+		assert.NoError(t, bindingRepo.DelAddressBinding(ctx, account, addr))
+		has, err = bindingRepo.HasAddressBinding(ctx, account, addr)
+		assert.NoError(t, err)
+		assert.False(t, has)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		addressBindingRepoTest(t, sqliteRepo.AddressBindingRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		addressBindingRepoTest(t, mysqlRepo.AddressBindingRepo())
+	})
+}
@@ -129,6 +129,14 @@ func TestAddress(t *testing.T) {
 			assert.Equal(t, num, r.SelMsgNum)
 		})
 
+		t.Run("UpdateWeight", func(t *testing.T) {
+			weight := int64(3)
+			assert.NoError(t, addressRepo.UpdateWeight(ctx, addrInfo.Addr, weight))
+			r, err := addressRepo.GetAddress(ctx, addrInfo.Addr)
+			assert.NoError(t, err)
+			assert.Equal(t, weight, r.Weight)
+		})
+
 		t.Run("UpdateFeeParams", func(t *testing.T) {
 			gasOverEstimation := 1.5
 			maxFeeCap := big.NewInt(1000)
@@ -142,6 +150,23 @@ func TestAddress(t *testing.T) {
 			assert.Equal(t, maxFeeCap, r.MaxFeeCap)
 		})
 
+		t.Run("UpdateFeeStrategy", func(t *testing.T) {
+			params := &types.FeeStrategyParams{
+				Strategy:          "fixed-premium",
+				FixedPremium:      big.NewInt(100),
+				BaseFeeMultiplier: 1.5,
+				PremiumPercentile: 60,
+			}
+			assert.NoError(t, addressRepo.UpdateFeeStrategy(ctx, addr, params))
+
+			r, err := addressRepo.GetAddress(ctx, addr)
+			assert.NoError(t, err)
+			assert.Equal(t, params.Strategy, r.FeeStrategy)
+			assert.Equal(t, params.FixedPremium, r.FixedPremium)
+			assert.Equal(t, params.BaseFeeMultiplier, r.BaseFeeMultiplier)
+			assert.Equal(t, params.PremiumPercentile, r.PremiumPercentile)
+		})
+
 		t.Run("DelAddress", func(t *testing.T) {
 			assert.NoError(t, addressRepo.DelAddress(ctx, addrInfo2.Addr))
 
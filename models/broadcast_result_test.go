@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestBroadcastResult(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	broadcastResultRepoTest := func(t *testing.T, resultRepo repo.BroadcastResultRepo) {
+		signedCid, err := abi.CidBuilder.Sum([]byte(types.NewUUID().String()))
+		assert.NoError(t, err)
+
+		assert.NoError(t, resultRepo.SaveBroadcastResults([]*types.BroadcastResult{
+			{SignedCid: signedCid, Node: "node2", Accepted: false, Error: "connection refused"},
+			{SignedCid: signedCid, Node: "node1", Accepted: true},
+		}))
+		list, err := resultRepo.ListBroadcastResult(signedCid)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, "node1", list[0].Node)
+		assert.True(t, list[0].Accepted)
+		assert.Equal(t, signedCid, list[0].SignedCid)
+		assert.Equal(t, "node2", list[1].Node)
+		assert.False(t, list[1].Accepted)
+		assert.Equal(t, "connection refused", list[1].Error)
+
+		// push again update the result
+		assert.NoError(t, resultRepo.SaveBroadcastResults([]*types.BroadcastResult{
+			{SignedCid: signedCid, Node: "node2", Accepted: true},
+		}))
+		list, err = resultRepo.ListBroadcastResult(signedCid)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.True(t, list[1].Accepted)
+		assert.Empty(t, list[1].Error)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		broadcastResultRepoTest(t, sqliteRepo.BroadcastResultRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		broadcastResultRepoTest(t, mysqlRepo.BroadcastResultRepo())
+	})
+}
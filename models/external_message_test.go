@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	venustypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestExternalMessage(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	externalMessageRepoTest := func(t *testing.T, extRepo repo.ExternalMessageRepo) {
+		from, err := address.NewIDAddress(uint64(time.Now().UnixNano()))
+		assert.NoError(t, err)
+		c, err := abi.CidBuilder.Sum([]byte(types.NewUUID().String()))
+		assert.NoError(t, err)
+
+		msg := &types.ExternalMessage{
+			Cid:           c,
+			From:          from,
+			Nonce:         10,
+			Height:        100,
+			TipSetKey:     venustypes.NewTipSetKey(c),
+			Receipt:       &venustypes.MessageReceipt{ExitCode: 0, GasUsed: 1000},
+			ConflictMsgID: "local-msg",
+		}
+		assert.NoError(t, extRepo.SaveExternalMessage(msg))
+
+		list, err := extRepo.ListExternalMessage(from)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, c, list[0].Cid)
+		assert.Equal(t, from, list[0].From)
+		assert.Equal(t, uint64(10), list[0].Nonce)
+		assert.Equal(t, int64(100), list[0].Height)
+		assert.Equal(t, msg.TipSetKey, list[0].TipSetKey)
+		assert.Equal(t, int64(1000), list[0].Receipt.GasUsed)
+		assert.Equal(t, "local-msg", list[0].ConflictMsgID)
+
+		// applied again keep the conflict
+		msg.ConflictMsgID = ""
+		msg.Height = 101
+		assert.NoError(t, extRepo.SaveExternalMessage(msg))
+		list, err = extRepo.ListExternalMessage(address.Undef)
+		assert.NoError(t, err)
+		var found *types.ExternalMessage
+		for _, m := range list {
+			if m.Cid == c {
+				found = m
+			}
+		}
+		assert.NotNil(t, found)
+		assert.Equal(t, int64(101), found.Height)
+		assert.Equal(t, "local-msg", found.ConflictMsgID)
+
+		assert.NoError(t, extRepo.DelExternalMessageByHeight(100))
+		list, err = extRepo.ListExternalMessage(from)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.NoError(t, extRepo.DelExternalMessageByHeight(101))
+		list, err = extRepo.ListExternalMessage(from)
+		assert.NoError(t, err)
+		assert.Len(t, list, 0)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		externalMessageRepoTest(t, sqliteRepo.ExternalMessageRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		externalMessageRepoTest(t, mysqlRepo.ExternalMessageRepo())
+	})
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestFeeHistory(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	feeHistoryRepoTest := func(t *testing.T, feeHistoryRepo repo.FeeHistoryRepo) {
+		now := time.Now()
+		height := abi.ChainEpoch(rand.Int63n(1 << 30))
+		history := &types.FeeHistory{
+			Height:        height,
+			TipsetKey:     types.NewUUID().String(),
+			BaseFee:       big.NewInt(100),
+			Timestamp:     uint64(now.Unix()),
+			MedianPremium: big.NewInt(10),
+			MsgCount:      2,
+			GasLimit:      1000,
+			PremiumPaid:   big.NewInt(5000),
+		}
+		assert.NoError(t, feeHistoryRepo.SaveFeeHistory(history))
+		assert.NoError(t, feeHistoryRepo.SaveFeeHistory(&types.FeeHistory{
+			Height:        height + 1,
+			BaseFee:       big.NewInt(200),
+			Timestamp:     uint64(now.Add(time.Hour).Unix()),
+			MedianPremium: big.NewInt(20),
+			PremiumPaid:   big.Zero(),
+		}))
+
+		list, err := feeHistoryRepo.ListFeeHistory(now.Add(-time.Minute), now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, ObjectToString(history), ObjectToString(list[0]))
+
+		// replace the history at the same height
+		history.BaseFee = big.NewInt(150)
+		assert.NoError(t, feeHistoryRepo.SaveFeeHistory(history))
+		list, err = feeHistoryRepo.ListFeeHistory(now.Add(-time.Minute), now.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, big.NewInt(150), list[0].BaseFee)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		feeHistoryRepoTest(t, sqliteRepo.FeeHistoryRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		feeHistoryRepoTest(t, mysqlRepo.FeeHistoryRepo())
+	})
+}
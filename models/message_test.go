@@ -10,6 +10,7 @@ import (
 	"github.com/filecoin-project/go-address"
 
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	venustypes "github.com/filecoin-project/venus/pkg/types"
 	"github.com/stretchr/testify/assert"
 
@@ -36,7 +37,7 @@ func TestSaveAndGetMessage(t *testing.T) {
 		afterSave := ObjectToString(result)
 		assert.Equal(t, beforeSave, afterSave)
 
-		allMsg, err := messageRepo.ListMessage()
+		allMsg, err := messageRepo.ListMessage("")
 		assert.NoError(t, err)
 		assert.LessOrEqual(t, 1, len(allMsg))
 
@@ -434,3 +435,304 @@ func TestListBlockedMessage(t *testing.T) {
 		})
 	})
 }
+
+func TestListUnChainMessageByAddressAndPriority(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		msgs := NewMessages(3)
+		for i, msg := range msgs {
+			msg.From = msgs[0].From
+			msg.Meta.Priority = types.MsgPriority(i)
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+		}
+
+		msgList, err := messageRepo.ListUnChainMessageByAddressAndPriority(msgs[0].From, types.PriorityHigh, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(msgList))
+		for _, msg := range msgList {
+			assert.GreaterOrEqual(t, int(msg.Meta.Priority), int(types.PriorityHigh))
+		}
+
+		count, err := messageRepo.CountUnChainMessageBelowPriority(msgs[0].From, types.PriorityHigh)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	}
+	t.Run("ListUnChainMessageByAddressAndPriority", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestUpdateMessageFeeByCid(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		msg := NewSignedMessages(1)[0]
+		assert.NoError(t, messageRepo.CreateMessage(msg))
+
+		fee := &types.MsgFee{
+			BaseFee:            big.NewInt(100),
+			BaseFeeBurn:        big.NewInt(1000),
+			OverEstimationBurn: big.NewInt(10),
+			MinerTip:           big.NewInt(200),
+		}
+		assert.NoError(t, messageRepo.UpdateMessageFeeByCid(msg.UnsignedCid.String(), fee))
+		result, err := messageRepo.GetMessageByUid(msg.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, ObjectToString(fee), ObjectToString(result.Fee))
+
+		assert.NoError(t, messageRepo.UpdateMessageFeeByCid(msg.UnsignedCid.String(), nil))
+		result, err = messageRepo.GetMessageByUid(msg.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, result.Fee)
+	}
+	t.Run("UpdateMessageFeeByCid", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestListSignedMessageSince(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		user := types.NewUUID().String()
+		since := time.Now().Add(-time.Hour)
+		signedAt := time.Now()
+		signedLongAgo := since.Add(-time.Hour)
+		msgs := NewSignedMessages(4)
+		for _, msg := range msgs {
+			msg.FromUser = user
+			msg.State = types.FillMsg
+		}
+		msgs[0].SignedAt = &signedAt
+		// updated recently but signed before since
+		msgs[1].SignedAt = &signedLongAgo
+		msgs[1].State = types.OnChainMsg
+		// signed before signed_at recorded
+		msgs[2].SignedAt = nil
+		msgs[3].SignedAt = &signedAt
+		msgs[3].State = types.UnFillMsg
+		for _, msg := range msgs {
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+		}
+
+		msgList, err := messageRepo.ListSignedMessageSince(address.Undef, user, since)
+		assert.NoError(t, err)
+		ids := make([]string, 0, len(msgList))
+		for _, msg := range msgList {
+			ids = append(ids, msg.ID)
+		}
+		assert.ElementsMatch(t, []string{msgs[0].ID, msgs[2].ID}, ids)
+
+		msgList, err = messageRepo.ListSignedMessageSince(msgs[0].From, user, since)
+		assert.NoError(t, err)
+		assert.Len(t, msgList, 1)
+		assert.Equal(t, signedAt.Unix(), msgList[0].SignedAt.Unix())
+	}
+	t.Run("ListSignedMessageSince", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestListOffChainMessageByTime(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		start := time.Now()
+		user := types.NewUUID().String()
+		msgs := NewMessages(5)
+		msgs[0].FromUser = user
+		msgs[1].FromUser = user
+		msgs[1].State = types.FailedMsg
+		msgs[2].FromUser = user
+		msgs[2].State = types.OnChainMsg
+		msgs[3].FromUser = user
+		msgs[3].State = types.ReplacedMsg
+		for _, msg := range msgs {
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+		}
+		end := time.Now().Add(time.Second)
+
+		msgList, err := messageRepo.ListOffChainMessageByTime(user, start, end)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(msgList))
+
+		msgList, err = messageRepo.ListOffChainMessageByTime("", start, end)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, 3, len(msgList))
+
+		msgList, err = messageRepo.ListOffChainMessageByTime(user, end, end.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(msgList))
+	}
+	t.Run("ListOffChainMessageByTime", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestScanOnChainMessage(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		user := types.NewUUID().String()
+		fee := &types.MsgFee{
+			BaseFee:            big.NewInt(100),
+			BaseFeeBurn:        big.NewInt(1000),
+			OverEstimationBurn: big.NewInt(10),
+			MinerTip:           big.NewInt(200),
+		}
+		msgs := NewSignedMessages(5)
+		for i, msg := range msgs {
+			msg.FromUser = user
+			msg.State = types.OnChainMsg
+			msg.Height = int64(100000 + i)
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+			assert.NoError(t, messageRepo.UpdateMessageFeeByCid(msg.UnsignedCid.String(), fee))
+		}
+		// not on chain
+		assert.NoError(t, messageRepo.UpdateMessageStateByID(msgs[3].ID, types.FailedMsg))
+		// landed as a replacement
+		msgs[4].Height = 100002
+		assert.NoError(t, messageRepo.UpdateMessageInfoByCid(msgs[4].UnsignedCid.String(), msgs[4].Receipt, 100002,
+			types.ReplacedMsg, msgs[4].TipSetKey))
+
+		var scanned []*types.Message
+		assert.NoError(t, messageRepo.ScanOnChainMessage(user, 100000, 100003, func(msg *types.Message) error {
+			scanned = append(scanned, msg)
+			return nil
+		}))
+		assert.Len(t, scanned, 4)
+		for _, msg := range scanned {
+			assert.Equal(t, user, msg.FromUser)
+			assert.Equal(t, ObjectToString(fee), ObjectToString(msg.Fee))
+		}
+
+		count := 0
+		assert.NoError(t, messageRepo.ScanOnChainMessage(types.NewUUID().String(), 100000, 100003, func(msg *types.Message) error {
+			count++
+			return nil
+		}))
+		assert.Equal(t, 0, count)
+
+		scanned = scanned[:0]
+		assert.NoError(t, messageRepo.ScanOnChainMessage(user, 100001, 100002, func(msg *types.Message) error {
+			scanned = append(scanned, msg)
+			return nil
+		}))
+		assert.Len(t, scanned, 1)
+		assert.Equal(t, msgs[1].ID, scanned[0].ID)
+	}
+	t.Run("ScanOnChainMessage", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestListUnChainMessageByAddressAndUser(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		userA := types.NewUUID().String()
+		userB := types.NewUUID().String()
+		msgs := NewMessages(3)
+		for i, msg := range msgs {
+			msg.From = msgs[0].From
+			msg.FromUser = userA
+			if i == 2 {
+				msg.FromUser = userB
+			}
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+		}
+
+		users, err := messageRepo.ListUnChainUserByAddress(msgs[0].From)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{userA, userB}, users)
+
+		msgList, err := messageRepo.ListUnChainMessageByAddressAndUser(msgs[0].From, userA, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(msgList))
+		for _, msg := range msgList {
+			assert.Equal(t, userA, msg.FromUser)
+		}
+
+		msgList, err = messageRepo.ListUnChainMessageByAddressAndUser(msgs[0].From, userA, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(msgList))
+	}
+	t.Run("ListUnChainMessageByAddressAndUser", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
+
+func TestListUnChainMessageByAddressOrder(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	messageRepoTest := func(t *testing.T, messageRepo repo.MessageRepo) {
+		msgs := NewMessages(3)
+		msgs[0].Meta.Priority = types.PriorityLow
+		msgs[2].Meta.Priority = types.PriorityHigh
+		for _, msg := range msgs {
+			msg.From = msgs[0].From
+			assert.NoError(t, messageRepo.CreateMessage(msg))
+			time.Sleep(time.Millisecond)
+		}
+
+		msgList, err := messageRepo.ListUnChainMessageByAddress(msgs[0].From, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(msgList))
+		assert.Equal(t, msgs[2].ID, msgList[0].ID)
+		assert.Equal(t, msgs[1].ID, msgList[1].ID)
+
+		count, err := messageRepo.CountUnChainMessageByAddress(msgs[0].From)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		counts, err := messageRepo.CountMessageGroupByAddress(types.UnFillMsg)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), counts[msgs[0].From])
+	}
+	t.Run("ListUnChainMessageByAddressOrder", func(t *testing.T) {
+		t.Run("sqlite", func(t *testing.T) {
+			messageRepoTest(t, sqliteRepo.MessageRepo())
+		})
+		t.Run("mysql", func(t *testing.T) {
+			t.SkipNow()
+			messageRepoTest(t, mysqlRepo.MessageRepo())
+		})
+	})
+}
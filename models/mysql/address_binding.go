@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type mysqlAddressBinding struct {
+	ID      types.UUID `gorm:"column:id;type:varchar(256);primary_key"`
+	Account string     `gorm:"column:account;type:varchar(256);uniqueIndex:idx_account_addr;NOT NULL"`
+	Addr    string     `gorm:"column:addr;type:varchar(256);uniqueIndex:idx_account_addr;NOT NULL"`
+	// comma separated method numbers, empty means all methods
+	Methods string `gorm:"column:methods;type:varchar(1024);"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s mysqlAddressBinding) TableName() string {
+	return "address_bindings"
+}
+
+func FromAddressBinding(binding *types.AddressBinding) *mysqlAddressBinding {
+	methods := make([]string, 0, len(binding.Methods))
+	for _, m := range binding.Methods {
+		methods = append(methods, strconv.FormatUint(uint64(m), 10))
+	}
+	return &mysqlAddressBinding{
+		ID:        binding.ID,
+		Account:   binding.Account,
+		Addr:      binding.Addr.String(),
+		Methods:   strings.Join(methods, ","),
+		CreatedAt: binding.CreatedAt,
+		UpdatedAt: binding.UpdatedAt,
+	}
+}
+
+func (s mysqlAddressBinding) AddressBinding() (*types.AddressBinding, error) {
+	addr, err := address.NewFromString(s.Addr)
+	if err != nil {
+		return nil, err
+	}
+	binding := &types.AddressBinding{
+		ID:        s.ID,
+		Account:   s.Account,
+		Addr:      addr,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if len(s.Methods) > 0 {
+		for _, str := range strings.Split(s.Methods, ",") {
+			m, err := strconv.ParseUint(str, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			binding.Methods = append(binding.Methods, abi.MethodNum(m))
+		}
+	}
+	return binding, nil
+}
+
+var _ repo.AddressBindingRepo = (*mysqlAddressBindingRepo)(nil)
+
+type mysqlAddressBindingRepo struct {
+	*gorm.DB
+}
+
+func newMysqlAddressBindingRepo(db *gorm.DB) *mysqlAddressBindingRepo {
+	return &mysqlAddressBindingRepo{DB: db}
+}
+
+// SaveAddressBinding replace the methods if account and address already bound
+func (s mysqlAddressBindingRepo) SaveAddressBinding(ctx context.Context, binding *types.AddressBinding) error {
+	var exist mysqlAddressBinding
+	err := s.DB.Take(&exist, "account = ? and addr = ?", binding.Account, binding.Addr.String()).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	mBinding := FromAddressBinding(binding)
+	mBinding.UpdatedAt = time.Now()
+	if err == nil {
+		mBinding.ID = exist.ID
+		mBinding.CreatedAt = exist.CreatedAt
+	} else if mBinding.CreatedAt.IsZero() {
+		mBinding.CreatedAt = mBinding.UpdatedAt
+	}
+	if err := s.DB.Save(mBinding).Error; err != nil {
+		return err
+	}
+	binding.ID = mBinding.ID
+	return nil
+}
+
+func (s mysqlAddressBindingRepo) GetAddressBinding(ctx context.Context, account string, addr address.Address) (*types.AddressBinding, error) {
+	var binding mysqlAddressBinding
+	if err := s.DB.Take(&binding, "account = ? and addr = ?", account, addr.String()).Error; err != nil {
+		return nil, err
+	}
+	return binding.AddressBinding()
+}
+
+func (s mysqlAddressBindingRepo) HasAddressBinding(ctx context.Context, account string, addr address.Address) (bool, error) {
+	var count int64
+	if err := s.DB.Model((*mysqlAddressBinding)(nil)).
+		Where("account = ? and addr = ?", account, addr.String()).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s mysqlAddressBindingRepo) ListAddressBinding(ctx context.Context, account string) ([]*types.AddressBinding, error) {
+	query := s.DB
+	if len(account) > 0 {
+		query = query.Where("account = ?", account)
+	}
+	var list []*mysqlAddressBinding
+	if err := query.Order("account, addr").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.AddressBinding, 0, len(list))
+	for _, r := range list {
+		binding, err := r.AddressBinding()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, binding)
+	}
+	return result, nil
+}
+
+func (s mysqlAddressBindingRepo) DelAddressBinding(ctx context.Context, account string, addr address.Address) error {
+	return s.DB.Where("account = ? and addr = ?", account, addr.String()).Delete(&mysqlAddressBinding{}).Error
+}
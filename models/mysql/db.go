@@ -31,6 +31,42 @@ func (d MysqlRepo) NodeRepo() repo.NodeRepo {
 	return newMysqlNodeRepo(d.DB)
 }
 
+func (d MysqlRepo) FeeHistoryRepo() repo.FeeHistoryRepo {
+	return newMysqlFeeHistoryRepo(d.DB)
+}
+
+func (d MysqlRepo) AddressBindingRepo() repo.AddressBindingRepo {
+	return newMysqlAddressBindingRepo(d.DB)
+}
+
+func (d MysqlRepo) SignPolicyRepo() repo.SignPolicyRepo {
+	return newMysqlSignPolicyRepo(d.DB)
+}
+
+func (d MysqlRepo) TokenRepo() repo.TokenRepo {
+	return newMysqlTokenRepo(d.DB)
+}
+
+func (d MysqlRepo) AuditRepo() repo.AuditRepo {
+	return newMysqlAuditRepo(d.DB)
+}
+
+func (d MysqlRepo) AccountLimitRepo() repo.AccountLimitRepo {
+	return newMysqlAccountLimitRepo(d.DB)
+}
+
+func (d MysqlRepo) AccountWeightRepo() repo.AccountWeightRepo {
+	return newMysqlAccountWeightRepo(d.DB)
+}
+
+func (d MysqlRepo) BroadcastResultRepo() repo.BroadcastResultRepo {
+	return newMysqlBroadcastResultRepo(d.DB)
+}
+
+func (d MysqlRepo) ExternalMessageRepo() repo.ExternalMessageRepo {
+	return newMysqlExternalMessageRepo(d.DB)
+}
+
 func (d MysqlRepo) AutoMigrate() error {
 	err := d.GetDb().AutoMigrate(mysqlMessage{})
 	if err != nil {
@@ -45,7 +81,43 @@ func (d MysqlRepo) AutoMigrate() error {
 		return err
 	}
 
-	return d.GetDb().AutoMigrate(mysqlNode{})
+	if err := d.GetDb().AutoMigrate(mysqlNode{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlFeeHistory{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlAddressBinding{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlSignPolicy{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlToken{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlAuditRecord{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlAccountLimit{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlAccountWeight{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(mysqlBroadcastResult{}); err != nil {
+		return err
+	}
+
+	return d.GetDb().AutoMigrate(mysqlExternalMessage{})
 }
 
 func (d MysqlRepo) GetDb() *gorm.DB {
@@ -79,6 +151,14 @@ func (t *TxMysqlRepo) AddressRepo() repo.AddressRepo {
 	return newMysqlAddressRepo(t.DB)
 }
 
+func (t *TxMysqlRepo) AddressBindingRepo() repo.AddressBindingRepo {
+	return newMysqlAddressBindingRepo(t.DB)
+}
+
+func (t *TxMysqlRepo) ExternalMessageRepo() repo.ExternalMessageRepo {
+	return newMysqlExternalMessageRepo(t.DB)
+}
+
 func OpenMysql(cfg *config.MySqlConfig) (repo.Repo, error) {
 	db, err := gorm.Open(mysql.Open(cfg.ConnectionString), &gorm.Config{
 		//Logger: logger.Default.LogMode(logger.Info), // 日志配置
@@ -0,0 +1,112 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+	"github.com/filecoin-project/venus-messager/utils"
+)
+
+type mysqlExternalMessage struct {
+	Cid       string              `gorm:"column:cid;type:varchar(256);primary_key"`
+	From      string              `gorm:"column:from_addr;type:varchar(256);index:ext_msg_from;NOT NULL"`
+	Nonce     uint64              `gorm:"column:nonce;type:bigint unsigned;NOT NULL"`
+	Height    int64               `gorm:"column:height;type:bigint"`
+	TipsetKey string              `gorm:"column:tipset_key;type:varchar(1024);"`
+	Receipt   *repo.SqlMsgReceipt `gorm:"embedded;embeddedPrefix:receipt_"`
+
+	ConflictMsgID string `gorm:"column:conflict_msg_id;type:varchar(256)"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s mysqlExternalMessage) TableName() string {
+	return "external_messages"
+}
+
+func FromExternalMessage(msg *types.ExternalMessage) *mysqlExternalMessage {
+	return &mysqlExternalMessage{
+		Cid:           msg.Cid.String(),
+		From:          msg.From.String(),
+		Nonce:         msg.Nonce,
+		Height:        msg.Height,
+		TipsetKey:     msg.TipSetKey.String(),
+		Receipt:       repo.FromMsgReceipt(msg.Receipt),
+		ConflictMsgID: msg.ConflictMsgID,
+		CreatedAt:     msg.CreatedAt,
+	}
+}
+
+func (s mysqlExternalMessage) ExternalMessage() *types.ExternalMessage {
+	msg := &types.ExternalMessage{
+		Nonce:         s.Nonce,
+		Height:        s.Height,
+		Receipt:       s.Receipt.MsgReceipt(),
+		ConflictMsgID: s.ConflictMsgID,
+		CreatedAt:     s.CreatedAt,
+	}
+	msg.Cid, _ = cid.Decode(s.Cid)
+	msg.From, _ = address.NewFromString(s.From)
+	if len(s.TipsetKey) > 0 {
+		msg.TipSetKey, _ = utils.StringToTipsetKey(s.TipsetKey)
+	}
+	return msg
+}
+
+var _ repo.ExternalMessageRepo = (*mysqlExternalMessageRepo)(nil)
+
+type mysqlExternalMessageRepo struct {
+	*gorm.DB
+}
+
+func newMysqlExternalMessageRepo(db *gorm.DB) *mysqlExternalMessageRepo {
+	return &mysqlExternalMessageRepo{DB: db}
+}
+
+func (s mysqlExternalMessageRepo) SaveExternalMessage(msg *types.ExternalMessage) error {
+	var exist mysqlExternalMessage
+	err := s.DB.Take(&exist, "cid = ?", msg.Cid.String()).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	sMsg := FromExternalMessage(msg)
+	sMsg.UpdatedAt = time.Now()
+	if err == nil {
+		sMsg.CreatedAt = exist.CreatedAt
+		// keep the conflict found when applied first time
+		if len(sMsg.ConflictMsgID) == 0 {
+			sMsg.ConflictMsgID = exist.ConflictMsgID
+		}
+	} else if sMsg.CreatedAt.IsZero() {
+		sMsg.CreatedAt = sMsg.UpdatedAt
+	}
+	return s.DB.Save(sMsg).Error
+}
+
+func (s mysqlExternalMessageRepo) ListExternalMessage(from address.Address) ([]*types.ExternalMessage, error) {
+	var list []*mysqlExternalMessage
+	query := s.DB
+	if from != address.Undef {
+		query = query.Where("from_addr = ?", from.String())
+	}
+	if err := query.Order("created_at desc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.ExternalMessage, 0, len(list))
+	for _, r := range list {
+		result = append(result, r.ExternalMessage())
+	}
+	return result, nil
+}
+
+func (s mysqlExternalMessageRepo) DelExternalMessageByHeight(height abi.ChainEpoch) error {
+	return s.DB.Where("height = ?", int64(height)).Delete(&mysqlExternalMessage{}).Error
+}
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type mysqlFeeHistory struct {
+	Height    abi.ChainEpoch `gorm:"column:height;type:bigint;primary_key;autoIncrement:false"`
+	TipsetKey string         `gorm:"column:tipset_key;type:varchar(2048);"`
+	BaseFee   types.Int      `gorm:"column:base_fee;type:varchar(256);NOT NULL"`
+	Timestamp uint64         `gorm:"column:timestamp;type:bigint unsigned;index;NOT NULL"`
+
+	MedianPremium types.Int `gorm:"column:median_premium;type:varchar(256);"`
+
+	MsgCount    int       `gorm:"column:msg_count;type:int;default:0"`
+	GasLimit    int64     `gorm:"column:gas_limit;type:bigint;default:0"`
+	PremiumPaid types.Int `gorm:"column:premium_paid;type:varchar(256);"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func FromFeeHistory(history *types.FeeHistory) *mysqlFeeHistory {
+	return &mysqlFeeHistory{
+		Height:        history.Height,
+		TipsetKey:     history.TipsetKey,
+		BaseFee:       types.Int{Int: history.BaseFee.Int},
+		Timestamp:     history.Timestamp,
+		MedianPremium: types.Int{Int: history.MedianPremium.Int},
+		MsgCount:      history.MsgCount,
+		GasLimit:      history.GasLimit,
+		PremiumPaid:   types.Int{Int: history.PremiumPaid.Int},
+	}
+}
+
+func (mfh mysqlFeeHistory) FeeHistory() *types.FeeHistory {
+	history := &types.FeeHistory{
+		Height:        mfh.Height,
+		TipsetKey:     mfh.TipsetKey,
+		BaseFee:       big.Zero(),
+		Timestamp:     mfh.Timestamp,
+		MedianPremium: big.Zero(),
+		MsgCount:      mfh.MsgCount,
+		GasLimit:      mfh.GasLimit,
+		PremiumPaid:   big.Zero(),
+	}
+	if !mfh.BaseFee.Nil() {
+		history.BaseFee = big.NewFromGo(mfh.BaseFee.Int)
+	}
+	if !mfh.MedianPremium.Nil() {
+		history.MedianPremium = big.NewFromGo(mfh.MedianPremium.Int)
+	}
+	if !mfh.PremiumPaid.Nil() {
+		history.PremiumPaid = big.NewFromGo(mfh.PremiumPaid.Int)
+	}
+	return history
+}
+
+func (mfh mysqlFeeHistory) TableName() string {
+	return "fee_histories"
+}
+
+var _ repo.FeeHistoryRepo = (*mysqlFeeHistoryRepo)(nil)
+
+type mysqlFeeHistoryRepo struct {
+	*gorm.DB
+}
+
+func newMysqlFeeHistoryRepo(db *gorm.DB) mysqlFeeHistoryRepo {
+	return mysqlFeeHistoryRepo{DB: db}
+}
+
+// SaveFeeHistory insert or replace the history at the same height, it happens when chain reorg
+func (s mysqlFeeHistoryRepo) SaveFeeHistory(history *types.FeeHistory) error {
+	mHistory := FromFeeHistory(history)
+	mHistory.CreatedAt = time.Now()
+	mHistory.UpdatedAt = time.Now()
+	return s.DB.Save(mHistory).Error
+}
+
+func (s mysqlFeeHistoryRepo) ListFeeHistory(start, end time.Time) ([]*types.FeeHistory, error) {
+	var internalHistory []*mysqlFeeHistory
+	if err := s.DB.Where("timestamp >= ? and timestamp <= ?", start.Unix(), end.Unix()).
+		Order("height").Find(&internalHistory).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.FeeHistory, 0, len(internalHistory))
+	for _, history := range internalHistory {
+		result = append(result, history.FeeHistory())
+	}
+	return result, nil
+}
@@ -42,6 +42,7 @@ type mysqlMessage struct {
 	Height    int64               `gorm:"column:height;type:bigint;index:msg_height"`
 	Receipt   *repo.SqlMsgReceipt `gorm:"embedded;embeddedPrefix:receipt_"`
 	TipsetKey string              `gorm:"column:tipset_key;type:varchar(1024);"`
+	Fee       *repo.SqlMsgFee     `gorm:"embedded;embeddedPrefix:fee_"`
 
 	Meta *MsgMeta `gorm:"embedded;embeddedPrefix:meta_"`
 
@@ -49,6 +50,8 @@ type mysqlMessage struct {
 	FromUser   string `gorm:"column:from_user;type:varchar(256)"`
 
 	State types.MessageState `gorm:"column:state;type:int;index:msg_state;index:msg_from_state;index:idx_messages_create_at_state_from_addr;"`
+	// time when the message signed, nil if not signed yet
+	SignedAt *time.Time `gorm:"column:signed_at;index"`
 
 	IsDeleted int       `gorm:"column:is_deleted;index;default:-1;NOT NULL"`                                   // 是否删除 1:是  -1:否
 	CreatedAt time.Time `gorm:"column:created_at;index;index:idx_messages_create_at_state_from_addr;NOT NULL"` // 创建时间
@@ -74,11 +77,13 @@ func (sqlMsg *mysqlMessage) Message() *types.Message {
 		},
 		Height:     sqlMsg.Height,
 		Receipt:    sqlMsg.Receipt.MsgReceipt(),
+		Fee:        sqlMsg.Fee.MsgFee(),
 		Signature:  (*crypto.Signature)(sqlMsg.Signature),
 		Meta:       sqlMsg.Meta.Meta(),
 		WalletName: sqlMsg.WalletName,
 		FromUser:   sqlMsg.FromUser,
 		State:      sqlMsg.State,
+		SignedAt:   sqlMsg.SignedAt,
 		UpdatedAt:  sqlMsg.UpdatedAt,
 		CreatedAt:  sqlMsg.CreatedAt,
 	}
@@ -112,10 +117,12 @@ func FromMessage(srcMsg *types.Message) *mysqlMessage {
 		Signature:  (*repo.SqlSignature)(srcMsg.Signature),
 		Height:     srcMsg.Height,
 		Receipt:    repo.FromMsgReceipt(srcMsg.Receipt),
+		Fee:        repo.FromMsgFee(srcMsg.Fee),
 		Meta:       FromMeta(srcMsg.Meta),
 		WalletName: srcMsg.WalletName,
 		FromUser:   srcMsg.FromUser,
 		State:      srcMsg.State,
+		SignedAt:   srcMsg.SignedAt,
 		IsDeleted:  repo.NotDeleted,
 	}
 
@@ -151,6 +158,7 @@ type MsgMeta struct {
 	GasOverEstimation float64        `gorm:"column:gas_over_estimation;type:decimal(10,2);"`
 	MaxFee            types.Int      `gorm:"column:max_fee;type:varchar(256);"`
 	MaxFeeCap         types.Int      `gorm:"column:max_fee_cap;type:varchar(256);"`
+	Priority          int            `gorm:"column:priority;type:int;default:0"`
 }
 
 func (meta *MsgMeta) Meta() *types.MsgMeta {
@@ -159,6 +167,7 @@ func (meta *MsgMeta) Meta() *types.MsgMeta {
 		GasOverEstimation: meta.GasOverEstimation,
 		MaxFee:            big.NewFromGo(meta.MaxFee.Int),
 		MaxFeeCap:         big.NewFromGo(meta.MaxFeeCap.Int),
+		Priority:          types.MsgPriority(meta.Priority),
 	}
 }
 
@@ -174,6 +183,7 @@ func FromMeta(srcMeta *types.MsgMeta) *MsgMeta {
 	meta := &MsgMeta{
 		ExpireEpoch:       srcMeta.ExpireEpoch,
 		GasOverEstimation: srcMeta.GasOverEstimation,
+		Priority:          int(srcMeta.Priority),
 	}
 
 	if srcMeta.MaxFee.Int != nil {
@@ -196,12 +206,16 @@ func newMysqlMessageRepo(db *gorm.DB) *mysqlMessageRepo {
 	return &mysqlMessageRepo{DB: db}
 }
 
-func (m *mysqlMessageRepo) ListMessageByFromState(from address.Address, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error) {
+// ListMessageByFromState list messages by page, undef from and empty fromUser means all
+func (m *mysqlMessageRepo) ListMessageByFromState(from address.Address, fromUser string, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error) {
 	query := m.DB.Table("messages").Offset((pageIndex - 1) * pageSize).Limit(pageSize)
 
 	if from != address.Undef {
 		query = query.Where("from_addr=?", from.String())
 	}
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
 	if state != types.OnChainMsg { // too much OnChainMsg, do not sort
 		query.Order("created_at")
 	}
@@ -301,7 +315,20 @@ func (m *mysqlMessageRepo) ListFilledMessageByHeight(height abi.ChainEpoch) ([]*
 
 func (m *mysqlMessageRepo) ListUnChainMessageByAddress(addr address.Address, topN int) ([]*types.Message, error) {
 	var sqlMsgs []*mysqlMessage
-	err := m.DB.Limit(topN).Order("created_at").Find(&sqlMsgs, "from_addr=? AND state=?", addr.String(), types.UnFillMsg).Error
+	err := m.DB.Limit(topN).Order("meta_priority desc, created_at").Find(&sqlMsgs, "from_addr=? AND state=?", addr.String(), types.UnFillMsg).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.Message, len(sqlMsgs))
+	for index, sqlMsg := range sqlMsgs {
+		result[index] = sqlMsg.Message()
+	}
+	return result, nil
+}
+
+func (m *mysqlMessageRepo) ListUnChainMessageByAddressAndPriority(addr address.Address, priority types.MsgPriority, topN int) ([]*types.Message, error) {
+	var sqlMsgs []*mysqlMessage
+	err := m.DB.Limit(topN).Order("created_at").Find(&sqlMsgs, "from_addr=? AND state=? AND meta_priority>=?", addr.String(), types.UnFillMsg, priority).Error
 	if err != nil {
 		return nil, err
 	}
@@ -312,6 +339,69 @@ func (m *mysqlMessageRepo) ListUnChainMessageByAddress(addr address.Address, top
 	return result, nil
 }
 
+func (m *mysqlMessageRepo) ListUnChainUserByAddress(addr address.Address) ([]string, error) {
+	var users []string
+	err := m.DB.Model((*mysqlMessage)(nil)).Where("from_addr=? AND state=?", addr.String(), types.UnFillMsg).Distinct().Pluck("from_user", &users).Error
+	return users, err
+}
+
+func (m *mysqlMessageRepo) ListUnChainMessageByAddressAndUser(addr address.Address, fromUser string, topN int) ([]*types.Message, error) {
+	var sqlMsgs []*mysqlMessage
+	err := m.DB.Limit(topN).Order("meta_priority desc, created_at").Find(&sqlMsgs, "from_addr=? AND state=? AND from_user=?", addr.String(), types.UnFillMsg, fromUser).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.Message, len(sqlMsgs))
+	for index, sqlMsg := range sqlMsgs {
+		result[index] = sqlMsg.Message()
+	}
+	return result, nil
+}
+
+func (m *mysqlMessageRepo) CountUnChainMessageByAddress(addr address.Address) (int64, error) {
+	var count int64
+	err := m.DB.Model((*mysqlMessage)(nil)).Where("from_addr=? AND state=?", addr.String(), types.UnFillMsg).Count(&count).Error
+	return count, err
+}
+
+func (m *mysqlMessageRepo) CountUnChainMessage() (int64, error) {
+	var count int64
+	err := m.DB.Model((*mysqlMessage)(nil)).Where("state=?", types.UnFillMsg).Count(&count).Error
+	return count, err
+}
+
+func (m *mysqlMessageRepo) CountMessageGroupByAddress(state types.MessageState) (map[address.Address]int64, error) {
+	var rows []struct {
+		FromAddr string
+		Count    int64
+	}
+	err := m.DB.Model(&mysqlMessage{}).Select("from_addr, count(*) as count").Where("state=?", state).Group("from_addr").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[address.Address]int64, len(rows))
+	for _, row := range rows {
+		addr, err := address.NewFromString(row.FromAddr)
+		if err != nil {
+			return nil, err
+		}
+		result[addr] = row.Count
+	}
+	return result, nil
+}
+
+func (m *mysqlMessageRepo) CountUnChainMessageBelowPriority(addr address.Address, priority types.MsgPriority) (int64, error) {
+	var count int64
+	err := m.DB.Model((*mysqlMessage)(nil)).Where("from_addr=? AND state=? AND meta_priority<?", addr.String(), types.UnFillMsg, priority).Count(&count).Error
+	return count, err
+}
+
+func (m *mysqlMessageRepo) CountPendingMessageByUser(fromUser string) (int64, error) {
+	var count int64
+	err := m.DB.Model((*mysqlMessage)(nil)).Where("from_user=? AND state in (?,?)", fromUser, types.UnFillMsg, types.FillMsg).Count(&count).Error
+	return count, err
+}
+
 //todo better batch update
 func (m *mysqlMessageRepo) BatchSaveMessage(msgs []*types.Message) error {
 	for _, msg := range msgs {
@@ -373,6 +463,74 @@ func (m *mysqlMessageRepo) GetSignedMessageByTime(start time.Time) ([]*types.Mes
 	return result, nil
 }
 
+func (m *mysqlMessageRepo) ScanOnChainMessage(fromUser string, startHeight, endHeight abi.ChainEpoch, fn func(msg *types.Message) error) error {
+	query := m.DB.Model(&mysqlMessage{}).
+		Where("height >= ? and height < ? and state in (?)", int64(startHeight), int64(endHeight),
+			[]types.MessageState{types.OnChainMsg, types.ReplacedMsg})
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sqlMsg mysqlMessage
+		if err := m.DB.ScanRows(rows, &sqlMsg); err != nil {
+			return err
+		}
+		if err := fn(sqlMsg.Message()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ListSignedMessageSince list messages signed since the time, undef from and empty fromUser means all
+func (m *mysqlMessageRepo) ListSignedMessageSince(from address.Address, fromUser string, since time.Time) ([]*types.Message, error) {
+	var sqlMsgs []*mysqlMessage
+	// messages signed before signed_at added use updated_at instead
+	query := m.DB.Where("(signed_at >= ? or (signed_at is null and updated_at >= ?)) and state in (?)", since, since,
+		[]types.MessageState{types.FillMsg, types.OnChainMsg, types.ReplacedMsg})
+	if from != address.Undef {
+		query = query.Where("from_addr = ?", from.String())
+	}
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
+	if err := query.Find(&sqlMsgs).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*types.Message, len(sqlMsgs))
+	for idx, msg := range sqlMsgs {
+		result[idx] = msg.Message()
+	}
+
+	return result, nil
+}
+
+// ListOffChainMessageByTime list messages created in [start, end) which are not on chain, replaced messages landed
+// on chain too, empty fromUser means all users
+func (m *mysqlMessageRepo) ListOffChainMessageByTime(fromUser string, start, end time.Time) ([]*types.Message, error) {
+	var sqlMsgs []*mysqlMessage
+	query := m.DB.Where("created_at >= ? and created_at < ? and state not in (?)", start, end,
+		[]types.MessageState{types.OnChainMsg, types.ReplacedMsg})
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
+	if err := query.Find(&sqlMsgs).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*types.Message, len(sqlMsgs))
+	for idx, msg := range sqlMsgs {
+		result[idx] = msg.Message()
+	}
+
+	return result, nil
+}
+
 func (m *mysqlMessageRepo) GetSignedMessageByHeight(height abi.ChainEpoch) ([]*types.Message, error) {
 	var sqlMsgs []*mysqlMessage
 	if err := m.DB.Where("height >= ? and signed_data is not null", uint64(height)).Find(&sqlMsgs).Error; err != nil {
@@ -402,9 +560,14 @@ func (m *mysqlMessageRepo) GetMessageByFromNonceAndState(from address.Address, n
 	return msg.Message(), nil
 }
 
-func (m *mysqlMessageRepo) ListMessage() ([]*types.Message, error) {
+// ListMessage list all messages, empty fromUser means all users
+func (m *mysqlMessageRepo) ListMessage(fromUser string) ([]*types.Message, error) {
 	var sqlMsgs []*mysqlMessage
-	if err := m.DB.Find(&sqlMsgs).Error; err != nil {
+	query := m.DB
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
+	if err := query.Find(&sqlMsgs).Error; err != nil {
 		return nil, err
 	}
 
@@ -415,9 +578,14 @@ func (m *mysqlMessageRepo) ListMessage() ([]*types.Message, error) {
 	return result, nil
 }
 
-func (m *mysqlMessageRepo) ListMessageByAddress(addr address.Address) ([]*types.Message, error) {
+// ListMessageByAddress list messages of address, empty fromUser means all users
+func (m *mysqlMessageRepo) ListMessageByAddress(addr address.Address, fromUser string) ([]*types.Message, error) {
 	var sqlMsgs []*mysqlMessage
-	if err := m.DB.Find(&sqlMsgs, "from_addr=?", addr.String()).Error; err != nil {
+	query := m.DB.Where("from_addr=?", addr.String())
+	if len(fromUser) > 0 {
+		query = query.Where("from_user = ?", fromUser)
+	}
+	if err := query.Find(&sqlMsgs).Error; err != nil {
 		return nil, err
 	}
 
@@ -506,6 +674,24 @@ func (m *mysqlMessageRepo) UpdateMessageInfoByCid(unsignedCid string,
 		UpdateColumns(updateClause).Error
 }
 
+// UpdateMessageFeeByCid update the fee actually paid by message, nil fee means clear it
+func (m *mysqlMessageRepo) UpdateMessageFeeByCid(unsignedCid string, fee *types.MsgFee) error {
+	sqlFee := repo.FromMsgFee(fee)
+	if sqlFee == nil {
+		sqlFee = &repo.SqlMsgFee{}
+	}
+	updateClause := map[string]interface{}{
+		"fee_base_fee":             sqlFee.BaseFee,
+		"fee_base_fee_burn":        sqlFee.BaseFeeBurn,
+		"fee_over_estimation_burn": sqlFee.OverEstimationBurn,
+		"fee_miner_tip":            sqlFee.MinerTip,
+		"updated_at":               time.Now(),
+	}
+	return m.DB.Model(&mysqlMessage{}).
+		Where("unsigned_cid = ?", unsignedCid).
+		UpdateColumns(updateClause).Error
+}
+
 func (m *mysqlMessageRepo) UpdateMessageStateByCid(cid string, state types.MessageState) error {
 	updateColumns := map[string]interface{}{
 		"state":      state,
@@ -21,10 +21,22 @@ type mysqlSharedParams struct {
 	MaxFee            types.Int      `gorm:"column:max_fee;type:varchar(256);NOT NULL"`
 	MaxFeeCap         types.Int      `gorm:"column:max_fee_cap;type:varchar(256);NOT NULL"`
 	SelMsgNum         uint64         `gorm:"column:sel_msg_num;type:BIGINT(20) UNSIGNED;NOT NULL"`
+	MaxSignPerEpoch   uint64         `gorm:"column:max_sign_per_epoch;type:BIGINT(20) UNSIGNED;default:0"`
 
 	ScanInterval int `gorm:"column:scan_interval;NOT NULL"`
 
 	MaxEstFailNumOfMsg uint64 `gorm:"column:max_ext_fail_num_of_msg;type:BIGINT(20) UNSIGNED;NOT NULL"`
+
+	FeeStrategy       string    `gorm:"column:fee_strategy;type:varchar(64);"`
+	FixedPremium      types.Int `gorm:"column:fixed_premium;type:varchar(256);"`
+	BaseFeeMultiplier float64   `gorm:"column:base_fee_multiplier;type:DOUBLE;"`
+	PremiumPercentile int       `gorm:"column:premium_percentile;type:int;"`
+	// comma separated method:strategy
+	MethodFeeStrategy string `gorm:"column:method_fee_strategy;type:varchar(1024);"`
+
+	BaseFeeThreshold types.Int `gorm:"column:base_fee_threshold;type:varchar(256);"`
+
+	FairShare bool `gorm:"column:fair_share;default:false"`
 }
 
 func FromSharedParams(sp types.SharedParams) *mysqlSharedParams {
@@ -35,8 +47,16 @@ func FromSharedParams(sp types.SharedParams) *mysqlSharedParams {
 		MaxFee:             types.Int{Int: sp.MaxFee.Int},
 		MaxFeeCap:          types.Int{Int: sp.MaxFeeCap.Int},
 		SelMsgNum:          sp.SelMsgNum,
+		MaxSignPerEpoch:    sp.MaxSignPerEpoch,
 		ScanInterval:       sp.ScanInterval,
 		MaxEstFailNumOfMsg: sp.MaxEstFailNumOfMsg,
+		FeeStrategy:        sp.FeeStrategy,
+		FixedPremium:       types.Int{Int: sp.FixedPremium.Int},
+		BaseFeeMultiplier:  sp.BaseFeeMultiplier,
+		PremiumPercentile:  sp.PremiumPercentile,
+		MethodFeeStrategy:  types.EncodeMethodFeeStrategy(sp.MethodFeeStrategy),
+		BaseFeeThreshold:   types.Int{Int: sp.BaseFeeThreshold.Int},
+		FairShare:          sp.FairShare,
 	}
 }
 
@@ -48,8 +68,16 @@ func (ssp mysqlSharedParams) SharedParams() *types.SharedParams {
 		MaxFee:             big.NewFromGo(ssp.MaxFee.Int),
 		MaxFeeCap:          big.NewFromGo(ssp.MaxFeeCap.Int),
 		SelMsgNum:          ssp.SelMsgNum,
+		MaxSignPerEpoch:    ssp.MaxSignPerEpoch,
 		ScanInterval:       ssp.ScanInterval,
 		MaxEstFailNumOfMsg: ssp.MaxEstFailNumOfMsg,
+		FeeStrategy:        ssp.FeeStrategy,
+		FixedPremium:       big.NewFromGo(ssp.FixedPremium.Int),
+		BaseFeeMultiplier:  ssp.BaseFeeMultiplier,
+		PremiumPercentile:  ssp.PremiumPercentile,
+		MethodFeeStrategy:  types.DecodeMethodFeeStrategy(ssp.MethodFeeStrategy),
+		BaseFeeThreshold:   big.NewFromGo(ssp.BaseFeeThreshold.Int),
+		FairShare:          ssp.FairShare,
 	}
 }
 
@@ -96,11 +124,22 @@ func (s mysqlSharedParamsRepo) SetSharedParams(ctx context.Context, params *type
 	ssp.MaxFee = types.Int{Int: params.MaxFee.Int}
 
 	ssp.SelMsgNum = params.SelMsgNum
+	ssp.MaxSignPerEpoch = params.MaxSignPerEpoch
 
 	ssp.ScanInterval = params.ScanInterval
 
 	ssp.MaxEstFailNumOfMsg = params.MaxEstFailNumOfMsg
 
+	ssp.FeeStrategy = params.FeeStrategy
+	ssp.FixedPremium = types.Int{Int: params.FixedPremium.Int}
+	ssp.BaseFeeMultiplier = params.BaseFeeMultiplier
+	ssp.PremiumPercentile = params.PremiumPercentile
+	ssp.MethodFeeStrategy = types.EncodeMethodFeeStrategy(params.MethodFeeStrategy)
+
+	ssp.BaseFeeThreshold = types.Int{Int: params.BaseFeeThreshold.Int}
+
+	ssp.FairShare = params.FairShare
+
 	if err := s.DB.Save(&ssp).Error; err != nil {
 		return 0, err
 	}
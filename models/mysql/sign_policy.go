@@ -0,0 +1,162 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type mysqlSignPolicy struct {
+	ID      types.UUID `gorm:"column:id;type:varchar(256);primary_key"`
+	Addr    string     `gorm:"column:addr;type:varchar(256);index"`
+	Account string     `gorm:"column:account;type:varchar(256);index"`
+
+	// comma separated addresses
+	AllowedTo string `gorm:"column:allowed_to;type:text;"`
+	// json of actor name to method names
+	AllowedMethods string    `gorm:"column:allowed_methods;type:text;"`
+	MaxValue       types.Int `gorm:"column:max_value;type:varchar(256);"`
+	MaxDailyValue  types.Int `gorm:"column:max_daily_value;type:varchar(256);"`
+	MaxFee         types.Int `gorm:"column:max_fee;type:varchar(256);"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s mysqlSignPolicy) TableName() string {
+	return "sign_policies"
+}
+
+func FromSignPolicy(policy *types.SignPolicy) (*mysqlSignPolicy, error) {
+	mPolicy := &mysqlSignPolicy{
+		ID:        policy.ID,
+		Account:   policy.Account,
+		CreatedAt: policy.CreatedAt,
+		UpdatedAt: policy.UpdatedAt,
+	}
+	if policy.Addr != address.Undef {
+		mPolicy.Addr = policy.Addr.String()
+	}
+	allowedTo := make([]string, 0, len(policy.AllowedTo))
+	for _, to := range policy.AllowedTo {
+		allowedTo = append(allowedTo, to.String())
+	}
+	mPolicy.AllowedTo = strings.Join(allowedTo, ",")
+	if len(policy.AllowedMethods) > 0 {
+		methods, err := json.Marshal(policy.AllowedMethods)
+		if err != nil {
+			return nil, err
+		}
+		mPolicy.AllowedMethods = string(methods)
+	}
+	if !policy.MaxValue.Nil() {
+		mPolicy.MaxValue = types.NewFromGo(policy.MaxValue.Int)
+	}
+	if !policy.MaxDailyValue.Nil() {
+		mPolicy.MaxDailyValue = types.NewFromGo(policy.MaxDailyValue.Int)
+	}
+	if !policy.MaxFee.Nil() {
+		mPolicy.MaxFee = types.NewFromGo(policy.MaxFee.Int)
+	}
+
+	return mPolicy, nil
+}
+
+func (s mysqlSignPolicy) SignPolicy() (*types.SignPolicy, error) {
+	policy := &types.SignPolicy{
+		ID:            s.ID,
+		Addr:          address.Undef,
+		Account:       s.Account,
+		MaxValue:      big.Zero(),
+		MaxDailyValue: big.Zero(),
+		MaxFee:        big.Zero(),
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+	var err error
+	if len(s.Addr) > 0 {
+		if policy.Addr, err = address.NewFromString(s.Addr); err != nil {
+			return nil, err
+		}
+	}
+	if len(s.AllowedTo) > 0 {
+		for _, str := range strings.Split(s.AllowedTo, ",") {
+			to, err := address.NewFromString(str)
+			if err != nil {
+				return nil, err
+			}
+			policy.AllowedTo = append(policy.AllowedTo, to)
+		}
+	}
+	if len(s.AllowedMethods) > 0 {
+		if err := json.Unmarshal([]byte(s.AllowedMethods), &policy.AllowedMethods); err != nil {
+			return nil, err
+		}
+	}
+	if !s.MaxValue.Nil() {
+		policy.MaxValue = big.NewFromGo(s.MaxValue.Int)
+	}
+	if !s.MaxDailyValue.Nil() {
+		policy.MaxDailyValue = big.NewFromGo(s.MaxDailyValue.Int)
+	}
+	if !s.MaxFee.Nil() {
+		policy.MaxFee = big.NewFromGo(s.MaxFee.Int)
+	}
+
+	return policy, nil
+}
+
+var _ repo.SignPolicyRepo = (*mysqlSignPolicyRepo)(nil)
+
+type mysqlSignPolicyRepo struct {
+	*gorm.DB
+}
+
+func newMysqlSignPolicyRepo(db *gorm.DB) *mysqlSignPolicyRepo {
+	return &mysqlSignPolicyRepo{DB: db}
+}
+
+func (s mysqlSignPolicyRepo) SaveSignPolicy(ctx context.Context, policy *types.SignPolicy) error {
+	mPolicy, err := FromSignPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return s.DB.Save(mPolicy).Error
+}
+
+func (s mysqlSignPolicyRepo) GetSignPolicy(ctx context.Context, id types.UUID) (*types.SignPolicy, error) {
+	var policy mysqlSignPolicy
+	if err := s.DB.Take(&policy, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return policy.SignPolicy()
+}
+
+func (s mysqlSignPolicyRepo) ListSignPolicy(ctx context.Context) ([]*types.SignPolicy, error) {
+	var list []*mysqlSignPolicy
+	if err := s.DB.Order("created_at").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.SignPolicy, 0, len(list))
+	for _, r := range list {
+		policy, err := r.SignPolicy()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, policy)
+	}
+	return result, nil
+}
+
+func (s mysqlSignPolicyRepo) DelSignPolicy(ctx context.Context, id types.UUID) error {
+	return s.DB.Where("id = ?", id).Delete(&mysqlSignPolicy{}).Error
+}
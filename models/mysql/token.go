@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type mysqlToken struct {
+	ID   types.UUID `gorm:"column:id;type:varchar(256);primary_key"`
+	Name string     `gorm:"column:name;type:varchar(256);index;NOT NULL"`
+	Perm string     `gorm:"column:perm;type:varchar(32);NOT NULL"`
+	// unix seconds, 0 means never expire
+	ExpireAt int64 `gorm:"column:expire_at;type:bigint;default:0"`
+	Revoked  bool  `gorm:"column:revoked;default:false"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s mysqlToken) TableName() string {
+	return "local_tokens"
+}
+
+func FromToken(token *types.LocalToken) *mysqlToken {
+	mToken := &mysqlToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		Perm:      token.Perm,
+		Revoked:   token.Revoked,
+		CreatedAt: token.CreatedAt,
+		UpdatedAt: token.UpdatedAt,
+	}
+	if !token.ExpireAt.IsZero() {
+		mToken.ExpireAt = token.ExpireAt.Unix()
+	}
+	return mToken
+}
+
+func (s mysqlToken) Token() *types.LocalToken {
+	token := &types.LocalToken{
+		ID:        s.ID,
+		Name:      s.Name,
+		Perm:      s.Perm,
+		Revoked:   s.Revoked,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if s.ExpireAt > 0 {
+		token.ExpireAt = time.Unix(s.ExpireAt, 0)
+	}
+	return token
+}
+
+var _ repo.TokenRepo = (*mysqlTokenRepo)(nil)
+
+type mysqlTokenRepo struct {
+	*gorm.DB
+}
+
+func newMysqlTokenRepo(db *gorm.DB) *mysqlTokenRepo {
+	return &mysqlTokenRepo{DB: db}
+}
+
+func (s mysqlTokenRepo) SaveToken(ctx context.Context, token *types.LocalToken) error {
+	return s.DB.Save(FromToken(token)).Error
+}
+
+func (s mysqlTokenRepo) GetToken(ctx context.Context, id types.UUID) (*types.LocalToken, error) {
+	var token mysqlToken
+	if err := s.DB.Take(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return token.Token(), nil
+}
+
+func (s mysqlTokenRepo) ListToken(ctx context.Context) ([]*types.LocalToken, error) {
+	var list []*mysqlToken
+	if err := s.DB.Order("created_at").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*types.LocalToken, 0, len(list))
+	for _, token := range list {
+		result = append(result, token.Token())
+	}
+	return result, nil
+}
+
+func (s mysqlTokenRepo) RevokeToken(ctx context.Context, id types.UUID) error {
+	return s.DB.Model((*mysqlToken)(nil)).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"revoked": true, "updated_at": time.Now()}).Error
+}
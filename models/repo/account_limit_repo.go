@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type AccountLimitRepo interface {
+	// SaveAccountLimit insert or update the limit of account
+	SaveAccountLimit(ctx context.Context, limit *types.AccountLimit) error
+	GetAccountLimit(ctx context.Context, account string) (*types.AccountLimit, error)
+	ListAccountLimit(ctx context.Context) ([]*types.AccountLimit, error)
+	DelAccountLimit(ctx context.Context, account string) error
+}
+
+type AccountWeightRepo interface {
+	// SaveAccountWeight insert or update the weight of account
+	SaveAccountWeight(ctx context.Context, weight *types.AccountWeight) error
+	ListAccountWeight(ctx context.Context) ([]*types.AccountWeight, error)
+	DelAccountWeight(ctx context.Context, account string) error
+}
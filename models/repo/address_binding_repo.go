@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type AddressBindingRepo interface {
+	// SaveAddressBinding insert or update the binding of account and address
+	SaveAddressBinding(ctx context.Context, binding *types.AddressBinding) error
+	GetAddressBinding(ctx context.Context, account string, addr address.Address) (*types.AddressBinding, error)
+	HasAddressBinding(ctx context.Context, account string, addr address.Address) (bool, error)
+	// ListAddressBinding list bindings of account, empty account means all accounts
+	ListAddressBinding(ctx context.Context, account string) ([]*types.AddressBinding, error)
+	DelAddressBinding(ctx context.Context, account string, addr address.Address) error
+}
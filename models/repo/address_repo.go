@@ -20,5 +20,8 @@ type AddressRepo interface {
 	UpdateNonce(ctx context.Context, addr address.Address, nonce uint64) error
 	UpdateState(ctx context.Context, addr address.Address, state types.State) error
 	UpdateSelectMsgNum(ctx context.Context, addr address.Address, num uint64) error
+	UpdateWeight(ctx context.Context, addr address.Address, weight int64) error
 	UpdateFeeParams(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap big.Int) error
+	UpdateFeeStrategy(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) error
+	UpdateBaseFeeThreshold(ctx context.Context, addr address.Address, threshold big.Int) error
 }
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type AuditRepo interface {
+	SaveAuditRecord(ctx context.Context, record *types.AuditRecord) error
+	// ListAuditRecord list records created in [start, end), the latest first
+	ListAuditRecord(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error)
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type BroadcastResultRepo interface {
+	// SaveBroadcastResults insert or update the result of message pushed to node
+	SaveBroadcastResults(results []*types.BroadcastResult) error
+	ListBroadcastResult(signedCid cid.Cid) ([]*types.BroadcastResult, error)
+}
@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type ExternalMessageRepo interface {
+	// SaveExternalMessage insert or update the message by cid
+	SaveExternalMessage(msg *types.ExternalMessage) error
+	// ListExternalMessage list messages of address, address.Undef means all addresses
+	ListExternalMessage(from address.Address) ([]*types.ExternalMessage, error)
+	// DelExternalMessageByHeight delete messages applied at the height, used when the tipset is reverted
+	DelExternalMessageByHeight(height abi.ChainEpoch) error
+}
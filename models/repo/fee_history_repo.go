@@ -0,0 +1,12 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type FeeHistoryRepo interface {
+	SaveFeeHistory(history *types.FeeHistory) error
+	ListFeeHistory(start, end time.Time) ([]*types.FeeHistory, error)
+}
@@ -27,12 +27,29 @@ type MessageRepo interface {
 	GetMessageBySignedCid(signedCid cid.Cid) (*types.Message, error)
 	GetSignedMessageByTime(start time.Time) ([]*types.Message, error)
 	GetSignedMessageByHeight(height abi.ChainEpoch) ([]*types.Message, error)
-	ListMessage() ([]*types.Message, error)
-	ListMessageByFromState(from address.Address, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error)
-	ListMessageByAddress(addr address.Address) ([]*types.Message, error)
+	// ScanOnChainMessage call fn with the messages landed in [startHeight, endHeight) one by one without loading all of
+	// them into memory, messages landed as a replacement are ReplacedMsg, empty fromUser means all users
+	ScanOnChainMessage(fromUser string, startHeight, endHeight abi.ChainEpoch, fn func(msg *types.Message) error) error
+	ListOffChainMessageByTime(fromUser string, start, end time.Time) ([]*types.Message, error)
+	ListSignedMessageSince(from address.Address, fromUser string, since time.Time) ([]*types.Message, error)
+	ListMessage(fromUser string) ([]*types.Message, error)
+	ListMessageByFromState(from address.Address, fromUser string, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error)
+	ListMessageByAddress(addr address.Address, fromUser string) ([]*types.Message, error)
 	ListFailedMessage() ([]*types.Message, error)
 	ListBlockedMessage(addr address.Address, d time.Duration) ([]*types.Message, error)
+	// ListUnChainMessageByAddress list unfilled messages of address, higher priority and earlier created first
 	ListUnChainMessageByAddress(addr address.Address, topN int) ([]*types.Message, error)
+	ListUnChainMessageByAddressAndPriority(addr address.Address, priority types.MsgPriority, topN int) ([]*types.Message, error)
+	// ListUnChainUserByAddress list accounts which have unfilled messages from address
+	ListUnChainUserByAddress(addr address.Address) ([]string, error)
+	ListUnChainMessageByAddressAndUser(addr address.Address, fromUser string, topN int) ([]*types.Message, error)
+	CountUnChainMessageByAddress(addr address.Address) (int64, error)
+	CountUnChainMessage() (int64, error)
+	// CountMessageGroupByAddress count messages in state of each address
+	CountMessageGroupByAddress(state types.MessageState) (map[address.Address]int64, error)
+	CountUnChainMessageBelowPriority(addr address.Address, priority types.MsgPriority) (int64, error)
+	// CountPendingMessageByUser count messages of account in UnFillMsg and FillMsg state
+	CountPendingMessageByUser(fromUser string) (int64, error)
 	ListFilledMessageByAddress(addr address.Address) ([]*types.Message, error)
 	ListFilledMessageByHeight(height abi.ChainEpoch) ([]*types.Message, error)
 	ListUnFilledMessage(addr address.Address) ([]*types.Message, error)
@@ -40,6 +57,7 @@ type MessageRepo interface {
 	ListFilledMessageBelowNonce(addr address.Address, nonce uint64) ([]*types.Message, error)
 
 	UpdateMessageInfoByCid(unsignedCid string, receipt *venustypes.MessageReceipt, height abi.ChainEpoch, state types.MessageState, tsKey venustypes.TipSetKey) error
+	UpdateMessageFeeByCid(unsignedCid string, fee *types.MsgFee) error
 	UpdateMessageStateByCid(unsignedCid string, state types.MessageState) error
 	UpdateMessageStateByID(id string, state types.MessageState) error
 	MarkBadMessage(id string) (struct{}, error)
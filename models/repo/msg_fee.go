@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type SqlMsgFee struct {
+	BaseFee            types.Int `gorm:"column:base_fee;type:varchar(256);"`
+	BaseFeeBurn        types.Int `gorm:"column:base_fee_burn;type:varchar(256);"`
+	OverEstimationBurn types.Int `gorm:"column:over_estimation_burn;type:varchar(256);"`
+	MinerTip           types.Int `gorm:"column:miner_tip;type:varchar(256);"`
+}
+
+// MsgFee return nil if the fee of message has not been computed
+func (s *SqlMsgFee) MsgFee() *types.MsgFee {
+	if s == nil || s.BaseFee.Nil() || s.BaseFee.Sign() == 0 {
+		return nil
+	}
+
+	return &types.MsgFee{
+		BaseFee:            big.NewFromGo(s.BaseFee.Int),
+		BaseFeeBurn:        intOrZero(s.BaseFeeBurn),
+		OverEstimationBurn: intOrZero(s.OverEstimationBurn),
+		MinerTip:           intOrZero(s.MinerTip),
+	}
+}
+
+func FromMsgFee(fee *types.MsgFee) *SqlMsgFee {
+	if fee == nil {
+		return nil
+	}
+
+	return &SqlMsgFee{
+		BaseFee:            types.Int{Int: fee.BaseFee.Int},
+		BaseFeeBurn:        types.Int{Int: fee.BaseFeeBurn.Int},
+		OverEstimationBurn: types.Int{Int: fee.OverEstimationBurn.Int},
+		MinerTip:           types.Int{Int: fee.MinerTip.Int},
+	}
+}
+
+func intOrZero(i types.Int) big.Int {
+	if i.Nil() {
+		return big.Zero()
+	}
+	return big.NewFromGo(i.Int)
+}
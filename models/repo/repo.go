@@ -26,11 +26,22 @@ type Repo interface {
 	AddressRepo() AddressRepo
 	SharedParamsRepo() SharedParamsRepo
 	NodeRepo() NodeRepo
+	FeeHistoryRepo() FeeHistoryRepo
+	AddressBindingRepo() AddressBindingRepo
+	SignPolicyRepo() SignPolicyRepo
+	TokenRepo() TokenRepo
+	AuditRepo() AuditRepo
+	AccountLimitRepo() AccountLimitRepo
+	AccountWeightRepo() AccountWeightRepo
+	BroadcastResultRepo() BroadcastResultRepo
+	ExternalMessageRepo() ExternalMessageRepo
 }
 
 type TxRepo interface {
 	MessageRepo() MessageRepo
 	AddressRepo() AddressRepo
+	AddressBindingRepo() AddressBindingRepo
+	ExternalMessageRepo() ExternalMessageRepo
 }
 
 type ISqlField interface {
@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type SignPolicyRepo interface {
+	SaveSignPolicy(ctx context.Context, policy *types.SignPolicy) error
+	GetSignPolicy(ctx context.Context, id types.UUID) (*types.SignPolicy, error)
+	ListSignPolicy(ctx context.Context) ([]*types.SignPolicy, error)
+	DelSignPolicy(ctx context.Context, id types.UUID) error
+}
@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type TokenRepo interface {
+	SaveToken(ctx context.Context, token *types.LocalToken) error
+	GetToken(ctx context.Context, id types.UUID) (*types.LocalToken, error)
+	ListToken(ctx context.Context) ([]*types.LocalToken, error)
+	RevokeToken(ctx context.Context, id types.UUID) error
+}
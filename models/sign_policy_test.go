@@ -0,0 +1,75 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestSignPolicy(t *testing.T) {
+	sqliteRepo, mysqlRepo := setupRepo(t)
+
+	signPolicyRepoTest := func(t *testing.T, policyRepo repo.SignPolicyRepo) {
+		ctx := context.Background()
+		to, err := address.NewIDAddress(1001)
+		assert.NoError(t, err)
+
+		policy := &types.SignPolicy{
+			ID:             types.NewUUID(),
+			Addr:           address.Undef,
+			Account:        "user",
+			AllowedTo:      []address.Address{to},
+			AllowedMethods: map[string][]string{"storageminer": {"PreCommitSector"}, "account": nil},
+			MaxValue:       big.NewInt(100),
+			MaxDailyValue:  big.NewInt(1000),
+			MaxFee:         big.Zero(),
+			CreatedAt:      time.Now().Round(time.Second),
+			UpdatedAt:      time.Now().Round(time.Second),
+		}
+		assert.NoError(t, policyRepo.SaveSignPolicy(ctx, policy))
+
+		r, err := policyRepo.GetSignPolicy(ctx, policy.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, address.Undef, r.Addr)
+		assert.Equal(t, policy.AllowedTo, r.AllowedTo)
+		assert.Equal(t, policy.AllowedMethods, r.AllowedMethods)
+		assert.Equal(t, policy.MaxValue, r.MaxValue)
+		assert.Equal(t, policy.MaxDailyValue, r.MaxDailyValue)
+		assert.True(t, r.MaxFee.IsZero())
+
+		policy.Addr = to
+		policy.MaxValue = big.NewInt(200)
+		assert.NoError(t, policyRepo.SaveSignPolicy(ctx, policy))
+		list, err := policyRepo.ListSignPolicy(ctx)
+		assert.NoError(t, err)
+		var found bool
+		for _, p := range list {
+			if p.ID == policy.ID {
+				found = true
+				assert.Equal(t, to, p.Addr)
+				assert.Equal(t, big.NewInt(200), p.MaxValue)
+			}
+		}
+		assert.True(t, found)
+
+		assert.NoError(t, policyRepo.DelSignPolicy(ctx, policy.ID))
+		_, err = policyRepo.GetSignPolicy(ctx, policy.ID)
+		assert.Error(t, err)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		signPolicyRepoTest(t, sqliteRepo.SignPolicyRepo())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		t.SkipNow()
+		signPolicyRepoTest(t, mysqlRepo.SignPolicyRepo())
+	})
+}
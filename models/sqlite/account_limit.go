@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type sqliteAccountLimit struct {
+	Account        string `gorm:"column:account;type:varchar(256);primary_key"`
+	PushPerMinute  int64  `gorm:"column:push_per_minute;type:bigint;default:0"`
+	MaxPendingMsg  int64  `gorm:"column:max_pending_msg;type:bigint;default:0"`
+	MaxParamsBytes int64  `gorm:"column:max_params_bytes;type:bigint;default:0"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s sqliteAccountLimit) TableName() string {
+	return "account_limits"
+}
+
+func FromAccountLimit(limit *types.AccountLimit) *sqliteAccountLimit {
+	return &sqliteAccountLimit{
+		Account:        limit.Account,
+		PushPerMinute:  limit.PushPerMinute,
+		MaxPendingMsg:  limit.MaxPendingMsg,
+		MaxParamsBytes: limit.MaxParamsBytes,
+		CreatedAt:      limit.CreatedAt,
+		UpdatedAt:      limit.UpdatedAt,
+	}
+}
+
+func (s sqliteAccountLimit) AccountLimit() *types.AccountLimit {
+	return &types.AccountLimit{
+		Account:        s.Account,
+		PushPerMinute:  s.PushPerMinute,
+		MaxPendingMsg:  s.MaxPendingMsg,
+		MaxParamsBytes: s.MaxParamsBytes,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+var _ repo.AccountLimitRepo = (*sqliteAccountLimitRepo)(nil)
+
+type sqliteAccountLimitRepo struct {
+	*gorm.DB
+}
+
+func newSqliteAccountLimitRepo(db *gorm.DB) *sqliteAccountLimitRepo {
+	return &sqliteAccountLimitRepo{DB: db}
+}
+
+func (s sqliteAccountLimitRepo) SaveAccountLimit(ctx context.Context, limit *types.AccountLimit) error {
+	var exist sqliteAccountLimit
+	err := s.DB.Take(&exist, "account = ?", limit.Account).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	sLimit := FromAccountLimit(limit)
+	sLimit.UpdatedAt = time.Now()
+	if err == nil {
+		sLimit.CreatedAt = exist.CreatedAt
+	} else if sLimit.CreatedAt.IsZero() {
+		sLimit.CreatedAt = sLimit.UpdatedAt
+	}
+	return s.DB.Save(sLimit).Error
+}
+
+func (s sqliteAccountLimitRepo) GetAccountLimit(ctx context.Context, account string) (*types.AccountLimit, error) {
+	var limit sqliteAccountLimit
+	if err := s.DB.Take(&limit, "account = ?", account).Error; err != nil {
+		return nil, err
+	}
+	return limit.AccountLimit(), nil
+}
+
+func (s sqliteAccountLimitRepo) ListAccountLimit(ctx context.Context) ([]*types.AccountLimit, error) {
+	var list []*sqliteAccountLimit
+	if err := s.DB.Order("account").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.AccountLimit, 0, len(list))
+	for _, r := range list {
+		result = append(result, r.AccountLimit())
+	}
+	return result, nil
+}
+
+func (s sqliteAccountLimitRepo) DelAccountLimit(ctx context.Context, account string) error {
+	return s.DB.Where("account = ?", account).Delete(&sqliteAccountLimit{}).Error
+}
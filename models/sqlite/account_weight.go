@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type sqliteAccountWeight struct {
+	Account string `gorm:"column:account;type:varchar(256);primary_key"`
+	Weight  int64  `gorm:"column:weight;type:bigint;default:1"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s sqliteAccountWeight) TableName() string {
+	return "account_weights"
+}
+
+func FromAccountWeight(weight *types.AccountWeight) *sqliteAccountWeight {
+	return &sqliteAccountWeight{
+		Account:   weight.Account,
+		Weight:    weight.Weight,
+		CreatedAt: weight.CreatedAt,
+		UpdatedAt: weight.UpdatedAt,
+	}
+}
+
+func (s sqliteAccountWeight) AccountWeight() *types.AccountWeight {
+	return &types.AccountWeight{
+		Account:   s.Account,
+		Weight:    s.Weight,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+var _ repo.AccountWeightRepo = (*sqliteAccountWeightRepo)(nil)
+
+type sqliteAccountWeightRepo struct {
+	*gorm.DB
+}
+
+func newSqliteAccountWeightRepo(db *gorm.DB) *sqliteAccountWeightRepo {
+	return &sqliteAccountWeightRepo{DB: db}
+}
+
+func (s sqliteAccountWeightRepo) SaveAccountWeight(ctx context.Context, weight *types.AccountWeight) error {
+	var exist sqliteAccountWeight
+	err := s.DB.Take(&exist, "account = ?", weight.Account).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+	sWeight := FromAccountWeight(weight)
+	sWeight.UpdatedAt = time.Now()
+	if err == nil {
+		sWeight.CreatedAt = exist.CreatedAt
+	} else if sWeight.CreatedAt.IsZero() {
+		sWeight.CreatedAt = sWeight.UpdatedAt
+	}
+	return s.DB.Save(sWeight).Error
+}
+
+func (s sqliteAccountWeightRepo) ListAccountWeight(ctx context.Context) ([]*types.AccountWeight, error) {
+	var list []*sqliteAccountWeight
+	if err := s.DB.Order("account").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.AccountWeight, 0, len(list))
+	for _, r := range list {
+		result = append(result, r.AccountWeight())
+	}
+	return result, nil
+}
+
+func (s sqliteAccountWeightRepo) DelAccountWeight(ctx context.Context, account string) error {
+	return s.DB.Where("account = ?", account).Delete(&sqliteAccountWeight{}).Error
+}
@@ -24,6 +24,11 @@ type sqliteAddress struct {
 	GasOverEstimation float64     `gorm:"column:gas_over_estimation;type:decimal(10,2);"`
 	MaxFee            types.Int   `gorm:"column:max_fee;type:varchar(256);"`
 	MaxFeeCap         types.Int   `gorm:"column:max_fee_cap;type:varchar(256);"`
+	FeeStrategy       string      `gorm:"column:fee_strategy;type:varchar(64);"`
+	FixedPremium      types.Int   `gorm:"column:fixed_premium;type:varchar(256);"`
+	BaseFeeMultiplier float64     `gorm:"column:base_fee_multiplier;type:decimal(10,2);"`
+	PremiumPercentile int         `gorm:"column:premium_percentile;type:int;"`
+	BaseFeeThreshold  types.Int   `gorm:"column:base_fee_threshold;type:varchar(256);"`
 
 	IsDeleted int       `gorm:"column:is_deleted;index;default:-1;NOT NULL"` // 是否删除 1:是  -1:否
 	CreatedAt time.Time `gorm:"column:created_at;index;NOT NULL"`            // 创建时间
@@ -43,6 +48,9 @@ func FromAddress(addr *types.Address) *sqliteAddress {
 		SelMsgNum:         addr.SelMsgNum,
 		State:             addr.State,
 		GasOverEstimation: addr.GasOverEstimation,
+		FeeStrategy:       addr.FeeStrategy,
+		BaseFeeMultiplier: addr.BaseFeeMultiplier,
+		PremiumPercentile: addr.PremiumPercentile,
 		IsDeleted:         addr.IsDeleted,
 		CreatedAt:         addr.CreatedAt,
 		UpdatedAt:         addr.UpdatedAt,
@@ -54,6 +62,12 @@ func FromAddress(addr *types.Address) *sqliteAddress {
 	if !addr.MaxFeeCap.Nil() {
 		sqliteAddr.MaxFeeCap = types.NewFromGo(addr.MaxFeeCap.Int)
 	}
+	if !addr.FixedPremium.Nil() {
+		sqliteAddr.FixedPremium = types.NewFromGo(addr.FixedPremium.Int)
+	}
+	if !addr.BaseFeeThreshold.Nil() {
+		sqliteAddr.BaseFeeThreshold = types.NewFromGo(addr.BaseFeeThreshold.Int)
+	}
 
 	return sqliteAddr
 }
@@ -74,6 +88,11 @@ func (s sqliteAddress) Address() (*types.Address, error) {
 		GasOverEstimation: s.GasOverEstimation,
 		MaxFee:            big.Int{Int: s.MaxFee.Int},
 		MaxFeeCap:         big.Int{Int: s.MaxFeeCap.Int},
+		FeeStrategy:       s.FeeStrategy,
+		FixedPremium:      big.Int{Int: s.FixedPremium.Int},
+		BaseFeeMultiplier: s.BaseFeeMultiplier,
+		PremiumPercentile: s.PremiumPercentile,
+		BaseFeeThreshold:  big.Int{Int: s.BaseFeeThreshold.Int},
 		IsDeleted:         s.IsDeleted,
 		CreatedAt:         s.CreatedAt,
 		UpdatedAt:         s.UpdatedAt,
@@ -161,6 +180,11 @@ func (s sqliteAddressRepo) UpdateSelectMsgNum(ctx context.Context, addr address.
 		UpdateColumns(map[string]interface{}{"sel_msg_num": num, "updated_at": time.Now()}).Error
 }
 
+func (s sqliteAddressRepo) UpdateWeight(ctx context.Context, addr address.Address, weight int64) error {
+	return s.DB.Model((*sqliteAddress)(nil)).Where("addr = ? and is_deleted = -1", addr.String()).
+		UpdateColumns(map[string]interface{}{"weight": weight, "updated_at": time.Now()}).Error
+}
+
 func (s sqliteAddressRepo) UpdateFeeParams(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFee, maxFeeCap big.Int) error {
 	updateColumns := make(map[string]interface{})
 	if gasOverEstimation != 0 {
@@ -177,6 +201,25 @@ func (s sqliteAddressRepo) UpdateFeeParams(ctx context.Context, addr address.Add
 	return s.DB.Model((*sqliteAddress)(nil)).Where("addr = ? and is_deleted = -1", addr.String()).UpdateColumns(updateColumns).Error
 }
 
+func (s sqliteAddressRepo) UpdateFeeStrategy(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) error {
+	updateColumns := map[string]interface{}{
+		"fee_strategy":        params.Strategy,
+		"base_fee_multiplier": params.BaseFeeMultiplier,
+		"premium_percentile":  params.PremiumPercentile,
+		"updated_at":          time.Now(),
+	}
+	if !params.FixedPremium.Nil() {
+		updateColumns["fixed_premium"] = types.NewFromGo(params.FixedPremium.Int)
+	}
+
+	return s.DB.Model((*sqliteAddress)(nil)).Where("addr = ? and is_deleted = -1", addr.String()).UpdateColumns(updateColumns).Error
+}
+
+func (s sqliteAddressRepo) UpdateBaseFeeThreshold(ctx context.Context, addr address.Address, threshold big.Int) error {
+	return s.DB.Model((*sqliteAddress)(nil)).Where("addr = ? and is_deleted = -1", addr.String()).
+		UpdateColumns(map[string]interface{}{"base_fee_threshold": types.NewFromGo(threshold.Int), "updated_at": time.Now()}).Error
+}
+
 func (s sqliteAddressRepo) DelAddress(ctx context.Context, addr address.Address) error {
 	return s.DB.Model((*sqliteAddress)(nil)).Where("addr = ? and is_deleted = -1", addr.String()).
 		UpdateColumns(map[string]interface{}{"is_deleted": repo.Deleted, "state": types.Removed, "updated_at": time.Now()}).Error
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type sqliteAuditRecord struct {
+	ID      types.UUID `gorm:"column:id;type:varchar(256);primary_key"`
+	Account string     `gorm:"column:account;type:varchar(256);index"`
+	IP      string     `gorm:"column:ip;type:varchar(256);"`
+	Method  string     `gorm:"column:method;type:varchar(256);index"`
+	Args    string     `gorm:"column:args;type:text;"`
+	Result  string     `gorm:"column:result;type:text;"`
+	Error   string     `gorm:"column:error;type:text;"`
+
+	CreatedAt time.Time `gorm:"column:created_at;index;NOT NULL"` // 创建时间
+}
+
+func (s sqliteAuditRecord) TableName() string {
+	return "audit_records"
+}
+
+func FromAuditRecord(record *types.AuditRecord) *sqliteAuditRecord {
+	return &sqliteAuditRecord{
+		ID:        record.ID,
+		Account:   record.Account,
+		IP:        record.IP,
+		Method:    record.Method,
+		Args:      record.Args,
+		Result:    record.Result,
+		Error:     record.Error,
+		CreatedAt: record.CreatedAt,
+	}
+}
+
+func (s sqliteAuditRecord) AuditRecord() *types.AuditRecord {
+	return &types.AuditRecord{
+		ID:        s.ID,
+		Account:   s.Account,
+		IP:        s.IP,
+		Method:    s.Method,
+		Args:      s.Args,
+		Result:    s.Result,
+		Error:     s.Error,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+var _ repo.AuditRepo = (*sqliteAuditRepo)(nil)
+
+type sqliteAuditRepo struct {
+	*gorm.DB
+}
+
+func newSqliteAuditRepo(db *gorm.DB) *sqliteAuditRepo {
+	return &sqliteAuditRepo{DB: db}
+}
+
+func (s sqliteAuditRepo) SaveAuditRecord(ctx context.Context, record *types.AuditRecord) error {
+	return s.DB.Create(FromAuditRecord(record)).Error
+}
+
+func (s sqliteAuditRepo) ListAuditRecord(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error) {
+	query := s.DB.Where("created_at >= ? and created_at < ?", params.Start, params.End)
+	if len(params.Account) > 0 {
+		query = query.Where("account = ?", params.Account)
+	}
+	if len(params.Method) > 0 {
+		query = query.Where("method = ?", params.Method)
+	}
+	query = query.Order("created_at desc")
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit)
+	}
+
+	var list []*sqliteAuditRecord
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*types.AuditRecord, 0, len(list))
+	for _, record := range list {
+		result = append(result, record.AuditRecord())
+	}
+	return result, nil
+}
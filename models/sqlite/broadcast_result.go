@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type sqliteBroadcastResult struct {
+	SignedCid string `gorm:"column:signed_cid;type:varchar(256);primary_key"`
+	Node      string `gorm:"column:node;type:varchar(256);primary_key"`
+	Accepted  bool   `gorm:"column:accepted"`
+	Error     string `gorm:"column:error;type:text"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func (s sqliteBroadcastResult) TableName() string {
+	return "broadcast_results"
+}
+
+func FromBroadcastResult(result *types.BroadcastResult) *sqliteBroadcastResult {
+	return &sqliteBroadcastResult{
+		SignedCid: result.SignedCid.String(),
+		Node:      result.Node,
+		Accepted:  result.Accepted,
+		Error:     result.Error,
+		CreatedAt: result.CreatedAt,
+		UpdatedAt: result.UpdatedAt,
+	}
+}
+
+func (s sqliteBroadcastResult) BroadcastResult() *types.BroadcastResult {
+	signedCid, _ := cid.Decode(s.SignedCid)
+	return &types.BroadcastResult{
+		SignedCid: signedCid,
+		Node:      s.Node,
+		Accepted:  s.Accepted,
+		Error:     s.Error,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+var _ repo.BroadcastResultRepo = (*sqliteBroadcastResultRepo)(nil)
+
+type sqliteBroadcastResultRepo struct {
+	*gorm.DB
+}
+
+func newSqliteBroadcastResultRepo(db *gorm.DB) *sqliteBroadcastResultRepo {
+	return &sqliteBroadcastResultRepo{DB: db}
+}
+
+func (s sqliteBroadcastResultRepo) SaveBroadcastResults(results []*types.BroadcastResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	now := time.Now()
+	list := make([]*sqliteBroadcastResult, 0, len(results))
+	for _, r := range results {
+		sResult := FromBroadcastResult(r)
+		sResult.CreatedAt, sResult.UpdatedAt = now, now
+		list = append(list, sResult)
+	}
+	return s.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "signed_cid"}, {Name: "node"}},
+		DoUpdates: clause.AssignmentColumns([]string{"accepted", "error", "updated_at"}),
+	}).Create(&list).Error
+}
+
+func (s sqliteBroadcastResultRepo) ListBroadcastResult(signedCid cid.Cid) ([]*types.BroadcastResult, error) {
+	var list []*sqliteBroadcastResult
+	if err := s.DB.Where("signed_cid = ?", signedCid.String()).Order("node").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.BroadcastResult, 0, len(list))
+	for _, r := range list {
+		result = append(result, r.BroadcastResult())
+	}
+	return result, nil
+}
@@ -29,6 +29,42 @@ func (d SqlLiteRepo) NodeRepo() repo.NodeRepo {
 	return newSqliteNodeRepo(d.DB)
 }
 
+func (d SqlLiteRepo) FeeHistoryRepo() repo.FeeHistoryRepo {
+	return newSqliteFeeHistoryRepo(d.DB)
+}
+
+func (d SqlLiteRepo) AddressBindingRepo() repo.AddressBindingRepo {
+	return newSqliteAddressBindingRepo(d.DB)
+}
+
+func (d SqlLiteRepo) SignPolicyRepo() repo.SignPolicyRepo {
+	return newSqliteSignPolicyRepo(d.DB)
+}
+
+func (d SqlLiteRepo) TokenRepo() repo.TokenRepo {
+	return newSqliteTokenRepo(d.DB)
+}
+
+func (d SqlLiteRepo) AuditRepo() repo.AuditRepo {
+	return newSqliteAuditRepo(d.DB)
+}
+
+func (d SqlLiteRepo) AccountLimitRepo() repo.AccountLimitRepo {
+	return newSqliteAccountLimitRepo(d.DB)
+}
+
+func (d SqlLiteRepo) AccountWeightRepo() repo.AccountWeightRepo {
+	return newSqliteAccountWeightRepo(d.DB)
+}
+
+func (d SqlLiteRepo) BroadcastResultRepo() repo.BroadcastResultRepo {
+	return newSqliteBroadcastResultRepo(d.DB)
+}
+
+func (d SqlLiteRepo) ExternalMessageRepo() repo.ExternalMessageRepo {
+	return newSqliteExternalMessageRepo(d.DB)
+}
+
 func (d SqlLiteRepo) AutoMigrate() error {
 	err := d.GetDb().AutoMigrate(sqliteMessage{})
 	if err != nil {
@@ -43,7 +79,43 @@ func (d SqlLiteRepo) AutoMigrate() error {
 		return err
 	}
 
-	return d.GetDb().AutoMigrate(sqliteNode{})
+	if err := d.GetDb().AutoMigrate(sqliteNode{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteFeeHistory{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteAddressBinding{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteSignPolicy{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteToken{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteAuditRecord{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteAccountLimit{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteAccountWeight{}); err != nil {
+		return err
+	}
+
+	if err := d.GetDb().AutoMigrate(sqliteBroadcastResult{}); err != nil {
+		return err
+	}
+
+	return d.GetDb().AutoMigrate(sqliteExternalMessage{})
 }
 
 func (d SqlLiteRepo) GetDb() *gorm.DB {
@@ -71,6 +143,14 @@ func (t *TxSqlliteRepo) AddressRepo() repo.AddressRepo {
 	return newSqliteAddressRepo(t.DB)
 }
 
+func (t *TxSqlliteRepo) AddressBindingRepo() repo.AddressBindingRepo {
+	return newSqliteAddressBindingRepo(t.DB)
+}
+
+func (t *TxSqlliteRepo) ExternalMessageRepo() repo.ExternalMessageRepo {
+	return newSqliteExternalMessageRepo(t.DB)
+}
+
 func (d SqlLiteRepo) DbClose() error {
 	// todo: if '*gorm.DB' need to dispose?
 	return nil
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type sqliteFeeHistory struct {
+	Height    abi.ChainEpoch `gorm:"column:height;type:bigint;primary_key;autoIncrement:false"`
+	TipsetKey string         `gorm:"column:tipset_key;type:varchar(2048);"`
+	BaseFee   types.Int      `gorm:"column:base_fee;type:varchar(256);NOT NULL"`
+	Timestamp uint64         `gorm:"column:timestamp;type:UNSIGNED BIG INT;index;NOT NULL"`
+
+	MedianPremium types.Int `gorm:"column:median_premium;type:varchar(256);"`
+
+	MsgCount    int       `gorm:"column:msg_count;type:int;default:0"`
+	GasLimit    int64     `gorm:"column:gas_limit;type:bigint;default:0"`
+	PremiumPaid types.Int `gorm:"column:premium_paid;type:varchar(256);"`
+
+	CreatedAt time.Time `gorm:"column:created_at;NOT NULL"` // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;NOT NULL"` // 更新时间
+}
+
+func FromFeeHistory(history *types.FeeHistory) *sqliteFeeHistory {
+	return &sqliteFeeHistory{
+		Height:        history.Height,
+		TipsetKey:     history.TipsetKey,
+		BaseFee:       types.Int{Int: history.BaseFee.Int},
+		Timestamp:     history.Timestamp,
+		MedianPremium: types.Int{Int: history.MedianPremium.Int},
+		MsgCount:      history.MsgCount,
+		GasLimit:      history.GasLimit,
+		PremiumPaid:   types.Int{Int: history.PremiumPaid.Int},
+	}
+}
+
+func (sfh sqliteFeeHistory) FeeHistory() *types.FeeHistory {
+	history := &types.FeeHistory{
+		Height:        sfh.Height,
+		TipsetKey:     sfh.TipsetKey,
+		BaseFee:       big.Zero(),
+		Timestamp:     sfh.Timestamp,
+		MedianPremium: big.Zero(),
+		MsgCount:      sfh.MsgCount,
+		GasLimit:      sfh.GasLimit,
+		PremiumPaid:   big.Zero(),
+	}
+	if !sfh.BaseFee.Nil() {
+		history.BaseFee = big.NewFromGo(sfh.BaseFee.Int)
+	}
+	if !sfh.MedianPremium.Nil() {
+		history.MedianPremium = big.NewFromGo(sfh.MedianPremium.Int)
+	}
+	if !sfh.PremiumPaid.Nil() {
+		history.PremiumPaid = big.NewFromGo(sfh.PremiumPaid.Int)
+	}
+	return history
+}
+
+func (sfh sqliteFeeHistory) TableName() string {
+	return "fee_histories"
+}
+
+var _ repo.FeeHistoryRepo = (*sqliteFeeHistoryRepo)(nil)
+
+type sqliteFeeHistoryRepo struct {
+	*gorm.DB
+}
+
+func newSqliteFeeHistoryRepo(db *gorm.DB) sqliteFeeHistoryRepo {
+	return sqliteFeeHistoryRepo{DB: db}
+}
+
+// SaveFeeHistory insert or replace the history at the same height, it happens when chain reorg
+func (s sqliteFeeHistoryRepo) SaveFeeHistory(history *types.FeeHistory) error {
+	sHistory := FromFeeHistory(history)
+	sHistory.CreatedAt = time.Now()
+	sHistory.UpdatedAt = time.Now()
+	return s.DB.Save(sHistory).Error
+}
+
+func (s sqliteFeeHistoryRepo) ListFeeHistory(start, end time.Time) ([]*types.FeeHistory, error) {
+	var internalHistory []*sqliteFeeHistory
+	if err := s.DB.Where("timestamp >= ? and timestamp <= ?", start.Unix(), end.Unix()).
+		Order("height").Find(&internalHistory).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.FeeHistory, 0, len(internalHistory))
+	for _, history := range internalHistory {
+		result = append(result, history.FeeHistory())
+	}
+	return result, nil
+}
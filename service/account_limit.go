@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// the window of push rate limit
+const pushRateWindow = time.Minute
+
+type AccountLimitService struct {
+	repo repo.Repo
+	log  *log.Logger
+
+	// pushes of accounts with limit
+	pushes *pushCounter
+
+	lk           sync.Mutex
+	accountLocks map[string]*sync.Mutex
+}
+
+func NewAccountLimitService(repo repo.Repo, logger *log.Logger) *AccountLimitService {
+	return &AccountLimitService{
+		repo:         repo,
+		log:          logger,
+		pushes:       newPushCounter(pushRateWindow),
+		accountLocks: make(map[string]*sync.Mutex),
+	}
+}
+
+// SetAccountLimit add or replace the limit of account, types.DefaultLimitAccount set the default limit
+func (limitService *AccountLimitService) SetAccountLimit(ctx context.Context, limit *types.AccountLimit) (struct{}, error) {
+	if len(limit.Account) == 0 {
+		return struct{}{}, xerrors.New("empty account")
+	}
+	if limit.PushPerMinute < 0 || limit.MaxPendingMsg < 0 || limit.MaxParamsBytes < 0 {
+		return struct{}{}, xerrors.New("limit must not be negative")
+	}
+	if err := limitService.repo.AccountLimitRepo().SaveAccountLimit(ctx, limit); err != nil {
+		return struct{}{}, err
+	}
+	limitService.log.Infof("set limit of account %s, push per minute %d, max pending msg %d, max params bytes %d",
+		limit.Account, limit.PushPerMinute, limit.MaxPendingMsg, limit.MaxParamsBytes)
+
+	return struct{}{}, nil
+}
+
+func (limitService *AccountLimitService) ListAccountLimit(ctx context.Context) ([]*types.AccountLimit, error) {
+	return limitService.repo.AccountLimitRepo().ListAccountLimit(ctx)
+}
+
+func (limitService *AccountLimitService) DeleteAccountLimit(ctx context.Context, account string) (struct{}, error) {
+	if _, err := limitService.repo.AccountLimitRepo().GetAccountLimit(ctx, account); err != nil {
+		return struct{}{}, xerrors.Errorf("get limit of account %s %w", account, err)
+	}
+	if err := limitService.repo.AccountLimitRepo().DelAccountLimit(ctx, account); err != nil {
+		return struct{}{}, err
+	}
+	limitService.log.Infof("delete limit of account %s", account)
+
+	return struct{}{}, nil
+}
+
+// SetAccountWeight set the share of account when fair share enabled, see types.SharedParams.FairShare
+func (limitService *AccountLimitService) SetAccountWeight(ctx context.Context, account string, weight int64) (struct{}, error) {
+	if len(account) == 0 {
+		return struct{}{}, xerrors.New("empty account")
+	}
+	if weight <= 0 {
+		return struct{}{}, xerrors.Errorf("weight must be positive, got %d", weight)
+	}
+	if err := limitService.repo.AccountWeightRepo().SaveAccountWeight(ctx, &types.AccountWeight{Account: account, Weight: weight}); err != nil {
+		return struct{}{}, err
+	}
+	limitService.log.Infof("set weight of account %s to %d", account, weight)
+
+	return struct{}{}, nil
+}
+
+func (limitService *AccountLimitService) ListAccountWeight(ctx context.Context) ([]*types.AccountWeight, error) {
+	return limitService.repo.AccountWeightRepo().ListAccountWeight(ctx)
+}
+
+// DeleteAccountWeight reset the weight of account to types.DefaultAccountWeight
+func (limitService *AccountLimitService) DeleteAccountWeight(ctx context.Context, account string) (struct{}, error) {
+	if err := limitService.repo.AccountWeightRepo().DelAccountWeight(ctx, account); err != nil {
+		return struct{}{}, err
+	}
+	limitService.log.Infof("delete weight of account %s", account)
+
+	return struct{}{}, nil
+}
+
+// GetAccountQuota return the limit and current usage of account, empty account means the caller's account
+func (limitService *AccountLimitService) GetAccountQuota(ctx context.Context, account string) (*types.AccountQuota, error) {
+	if scopedAccount, ok := scopedAccountFromContext(ctx); ok {
+		account = scopedAccount
+	}
+	if len(account) == 0 {
+		_, account = ipAccountFromContext(ctx)
+	}
+
+	limit, err := limitService.accountLimit(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := limitService.repo.MessageRepo().CountPendingMessageByUser(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AccountQuota{
+		Account:          account,
+		Limit:            limit,
+		PushesLastMinute: limitService.pushes.count(account, time.Now()),
+		PendingMsg:       pending,
+	}, nil
+}
+
+// accountLimit return the limit of account, fallback to the default limit, nil means unlimited
+func (limitService *AccountLimitService) accountLimit(ctx context.Context, account string) (*types.AccountLimit, error) {
+	for _, name := range []string{account, types.DefaultLimitAccount} {
+		limit, err := limitService.repo.AccountLimitRepo().GetAccountLimit(ctx, name)
+		if err == nil {
+			return limit, nil
+		}
+		if !xerrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// admitPush check the message against the limit of account and call save to insert it, the push is counted after
+// saved. checks and inserts of the same account are serialized, so concurrent pushes can not exceed the limit
+func (limitService *AccountLimitService) admitPush(ctx context.Context, msg *types.Message, save func() error) error {
+	limit, err := limitService.accountLimit(ctx, msg.FromUser)
+	if err != nil {
+		return xerrors.Errorf("get limit of account %s failed %v", msg.FromUser, err)
+	}
+	if limit == nil {
+		return save()
+	}
+
+	if limit.MaxParamsBytes > 0 && int64(len(msg.Params)) > limit.MaxParamsBytes {
+		return xerrors.Errorf("params of message is %d bytes, exceeds the limit %d bytes of account %s",
+			len(msg.Params), limit.MaxParamsBytes, msg.FromUser)
+	}
+
+	lk := limitService.accountLock(msg.FromUser)
+	lk.Lock()
+	defer lk.Unlock()
+
+	if limit.MaxPendingMsg > 0 {
+		pending, err := limitService.repo.MessageRepo().CountPendingMessageByUser(msg.FromUser)
+		if err != nil {
+			return xerrors.Errorf("count pending message of account %s failed %v", msg.FromUser, err)
+		}
+		if pending >= limit.MaxPendingMsg {
+			return xerrors.Errorf("account %s has %d pending messages, reaches the limit %d", msg.FromUser, pending, limit.MaxPendingMsg)
+		}
+	}
+
+	if limit.PushPerMinute > 0 && limitService.pushes.count(msg.FromUser, time.Now()) >= limit.PushPerMinute {
+		return xerrors.Errorf("account %s exceeds the limit of %d pushes per minute", msg.FromUser, limit.PushPerMinute)
+	}
+
+	if err := save(); err != nil {
+		return err
+	}
+	limitService.pushes.record(msg.FromUser, time.Now())
+
+	return nil
+}
+
+func (limitService *AccountLimitService) accountLock(account string) *sync.Mutex {
+	limitService.lk.Lock()
+	defer limitService.lk.Unlock()
+
+	lk, ok := limitService.accountLocks[account]
+	if !ok {
+		lk = &sync.Mutex{}
+		limitService.accountLocks[account] = lk
+	}
+	return lk
+}
+
+// pushCounter count the pushes of accounts in a sliding window
+type pushCounter struct {
+	lk     sync.Mutex
+	window time.Duration
+	pushes map[string][]time.Time
+}
+
+func newPushCounter(window time.Duration) *pushCounter {
+	return &pushCounter{window: window, pushes: make(map[string][]time.Time)}
+}
+
+func (pc *pushCounter) record(account string, now time.Time) {
+	pc.lk.Lock()
+	defer pc.lk.Unlock()
+
+	pc.pushes[account] = append(pc.prune(account, now), now)
+}
+
+func (pc *pushCounter) count(account string, now time.Time) int64 {
+	pc.lk.Lock()
+	defer pc.lk.Unlock()
+
+	return int64(len(pc.prune(account, now)))
+}
+
+func (pc *pushCounter) prune(account string, now time.Time) []time.Time {
+	pushes := pc.pushes[account]
+	idx := 0
+	for idx < len(pushes) && now.Sub(pushes[idx]) >= pc.window {
+		idx++
+	}
+	pushes = pushes[idx:]
+	if len(pushes) == 0 {
+		delete(pc.pushes, account)
+	} else {
+		pc.pushes[account] = pushes
+	}
+	return pushes
+}
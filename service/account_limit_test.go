@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestPushCounter(t *testing.T) {
+	pc := newPushCounter(time.Minute)
+	now := time.Now()
+
+	pc.record("a", now)
+	pc.record("a", now.Add(10*time.Second))
+	assert.Equal(t, int64(2), pc.count("a", now.Add(20*time.Second)))
+
+	// other accounts are not affected
+	assert.Equal(t, int64(0), pc.count("b", now.Add(20*time.Second)))
+
+	// the first push leaves the window
+	pc.record("a", now.Add(time.Minute))
+	assert.Equal(t, int64(2), pc.count("a", now.Add(time.Minute)))
+	assert.Equal(t, int64(0), pc.count("a", now.Add(3*time.Minute)))
+}
+
+func TestAdmitPush(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "admit_push.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("admit_push.db"))
+		assert.NoError(t, os.Remove("admit_push.db-shm"))
+		assert.NoError(t, os.Remove("admit_push.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	ctx := context.Background()
+	limitService := NewAccountLimitService(db, log.New())
+	_, err = limitService.SetAccountLimit(ctx, &types.AccountLimit{Account: "a", MaxPendingMsg: 3, PushPerMinute: 10})
+	assert.NoError(t, err)
+
+	push := func(account string, save func(msg *types.Message) error) error {
+		msg := models.NewMessage()
+		msg.FromUser = account
+		return limitService.admitPush(ctx, msg, func() error {
+			return save(msg)
+		})
+	}
+	create := func(msg *types.Message) error {
+		return db.MessageRepo().CreateMessage(msg)
+	}
+
+	// failed insert is not counted
+	assert.Error(t, push("a", func(msg *types.Message) error {
+		return xerrors.New("insert failed")
+	}))
+	assert.Equal(t, int64(0), limitService.pushes.count("a", time.Now()))
+
+	// concurrent pushes not exceed max pending messages
+	var wg sync.WaitGroup
+	var lk sync.Mutex
+	admitted := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if push("a", create) == nil {
+				lk.Lock()
+				admitted++
+				lk.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, admitted)
+	assert.Equal(t, int64(3), limitService.pushes.count("a", time.Now()))
+
+	// pushes of account without limit are not recorded
+	assert.NoError(t, push("b", create))
+	assert.Equal(t, int64(0), limitService.pushes.count("b", time.Now()))
+}
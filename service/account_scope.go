@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type scopedAccountKey struct{}
+
+// WithScopedAccount return a context which can only access the messages and addresses of account,
+// it is set by api layer for non-admin callers when account scoped mode enabled
+func WithScopedAccount(ctx context.Context, account string) context.Context {
+	return context.WithValue(ctx, scopedAccountKey{}, account)
+}
+
+// scopedAccountFromContext return the account which caller is limited to, false means no limit
+func scopedAccountFromContext(ctx context.Context) (string, bool) {
+	account, ok := ctx.Value(scopedAccountKey{}).(string)
+	return account, ok
+}
+
+// checkMessageScope return not found error if the message does not belong to the scoped account
+func checkMessageScope(ctx context.Context, msg *types.Message) error {
+	if account, ok := scopedAccountFromContext(ctx); ok && msg.FromUser != account {
+		return xerrors.Errorf("message %s %w", msg.ID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func filterMessageByScope(ctx context.Context, msgs []*types.Message) []*types.Message {
+	account, ok := scopedAccountFromContext(ctx)
+	if !ok {
+		return msgs
+	}
+	result := make([]*types.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.FromUser == account {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+// addressInScope check whether the address bound to the scoped account, by the binding table if required,
+// otherwise by the wallet of account
+func (addressService *AddressService) addressInScope(ctx context.Context, addr address.Address) (bool, error) {
+	account, ok := scopedAccountFromContext(ctx)
+	if !ok {
+		return true, nil
+	}
+	if addressService.cfg.RequireAddressBinding {
+		return addressService.repo.AddressBindingRepo().HasAddressBinding(ctx, account, addr)
+	}
+	return addressService.walletClient.WalletHas(ctx, account, addr)
+}
+
+// scopedMessage wrap the result of repo to check the scope of message
+func scopedMessage(ctx context.Context) func(msg *types.Message, err error) (*types.Message, error) {
+	return func(msg *types.Message, err error) (*types.Message, error) {
+		if err != nil {
+			return nil, err
+		}
+		if err := checkMessageScope(ctx, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestAccountScope(t *testing.T) {
+	msgs := []*types.Message{
+		{ID: "1", FromUser: "a"},
+		{ID: "2", FromUser: "b"},
+		{ID: "3", FromUser: "a"},
+	}
+
+	ctx := context.Background()
+	_, ok := scopedAccountFromContext(ctx)
+	assert.False(t, ok)
+	assert.Len(t, filterMessageByScope(ctx, msgs), 3)
+	assert.NoError(t, checkMessageScope(ctx, msgs[1]))
+
+	ctx = WithScopedAccount(ctx, "a")
+	account, ok := scopedAccountFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "a", account)
+
+	filtered := filterMessageByScope(ctx, msgs)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "1", filtered[0].ID)
+	assert.Equal(t, "3", filtered[1].ID)
+
+	assert.NoError(t, checkMessageScope(ctx, msgs[0]))
+	err := checkMessageScope(ctx, msgs[1])
+	assert.True(t, xerrors.Is(err, gorm.ErrRecordNotFound))
+
+	_, err = scopedMessage(ctx)(msgs[1], nil)
+	assert.Error(t, err)
+	msg, err := scopedMessage(ctx)(msgs[2], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, msgs[2], msg)
+}
+
+func TestScopedMessageQuery(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "account_scope.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("account_scope.db"))
+		assert.NoError(t, os.Remove("account_scope.db-shm"))
+		assert.NoError(t, os.Remove("account_scope.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	// more messages of other account than a page, they should not take the page of scoped account
+	msgs := models.NewMessages(4)
+	for i, msg := range msgs {
+		msg.From = msgs[0].From
+		msg.FromUser = "b"
+		if i == 3 {
+			msg.FromUser = "a"
+		}
+		assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+	}
+
+	head := newMockTipSet(t, 10)
+	ms := &MessageService{
+		repo: db,
+		log:  log.New(),
+		nodeClient: &NodeClient{
+			ChainHead: func(context.Context) (*venusTypes.TipSet, error) {
+				return head, nil
+			},
+		},
+	}
+	ctx := WithScopedAccount(context.Background(), "a")
+
+	page, err := ms.ListMessageByFromState(ctx, address.Undef, types.UnFillMsg, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, msgs[3].ID, page[0].ID)
+
+	list, err := ms.ListMessage(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	list, err = ms.ListMessageByAddress(ctx, msgs[0].From)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	state, err := ms.GetMessageState(ctx, msgs[3].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.UnFillMsg, state)
+	_, err = ms.GetMessageState(ctx, msgs[0].ID)
+	assert.True(t, xerrors.Is(err, gorm.ErrRecordNotFound))
+
+	list, err = ms.ListMessage(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, list, 4)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// BindAddress allow account to push messages from address, the address will be added if not exists
+func (addressService *AddressService) BindAddress(ctx context.Context, binding *types.AddressBinding) (types.UUID, error) {
+	if len(binding.Account) == 0 {
+		return types.UUID{}, xerrors.New("empty account")
+	}
+	if binding.Addr.Protocol() == address.ID {
+		return types.UUID{}, xerrors.Errorf("bind ID address %s is not supported, use key address", binding.Addr)
+	}
+	if binding.ID == (types.UUID{}) {
+		binding.ID = types.NewUUID()
+	}
+
+	err := addressService.repo.Transaction(func(txRepo repo.TxRepo) error {
+		has, err := txRepo.AddressRepo().HasAddress(ctx, binding.Addr)
+		if err != nil {
+			return err
+		}
+		if !has {
+			if err := txRepo.AddressRepo().SaveAddress(ctx, &types.Address{
+				ID:        types.NewUUID(),
+				Addr:      binding.Addr,
+				Nonce:     0,
+				State:     types.Alive,
+				IsDeleted: repo.NotDeleted,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}); err != nil {
+				return xerrors.Errorf("save address %s failed %v", binding.Addr, err)
+			}
+			addressService.log.Infof("add new address %s", binding.Addr)
+		}
+		return txRepo.AddressBindingRepo().SaveAddressBinding(ctx, binding)
+	})
+	if err != nil {
+		return types.UUID{}, err
+	}
+	addressService.log.Infof("bind account %s to address %s, methods %v", binding.Account, binding.Addr, binding.Methods)
+
+	return binding.ID, nil
+}
+
+func (addressService *AddressService) UnbindAddress(ctx context.Context, account string, addr address.Address) (struct{}, error) {
+	has, err := addressService.repo.AddressBindingRepo().HasAddressBinding(ctx, account, addr)
+	if err != nil {
+		return struct{}{}, err
+	}
+	if !has {
+		return struct{}{}, xerrors.Errorf("account %s not bound to address %s", account, addr)
+	}
+	return struct{}{}, addressService.repo.AddressBindingRepo().DelAddressBinding(ctx, account, addr)
+}
+
+// ListAddressBinding list bindings of account, empty account means all accounts
+func (addressService *AddressService) ListAddressBinding(ctx context.Context, account string) ([]*types.AddressBinding, error) {
+	return addressService.repo.AddressBindingRepo().ListAddressBinding(ctx, account)
+}
+
+// checkAddressBinding return error if account not bound to address or not allowed to call method
+func (addressService *AddressService) checkAddressBinding(ctx context.Context, account string, addr address.Address, method abi.MethodNum) error {
+	binding, err := addressService.repo.AddressBindingRepo().GetAddressBinding(ctx, account, addr)
+	if err != nil {
+		if xerrors.Is(err, gorm.ErrRecordNotFound) {
+			return xerrors.Errorf("account %s not bound to address %s", account, addr)
+		}
+		return err
+	}
+	if !binding.AllowMethod(method) {
+		return xerrors.Errorf("account %s not allowed to call method %d from address %s", account, method, addr)
+	}
+	return nil
+}
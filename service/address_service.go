@@ -9,6 +9,7 @@ import (
 	"golang.org/x/xerrors"
 	"gorm.io/gorm"
 
+	"github.com/filecoin-project/venus-messager/config"
 	"github.com/filecoin-project/venus-messager/gateway"
 	"github.com/filecoin-project/venus-messager/log"
 	"github.com/filecoin-project/venus-messager/models/repo"
@@ -20,6 +21,7 @@ var errAddressNotExists = xerrors.New("address not exists")
 type AddressService struct {
 	repo repo.Repo
 	log  *log.Logger
+	cfg  *config.MessageServiceConfig
 
 	sps          *SharedParamsService
 	nodeClient   *NodeClient
@@ -31,12 +33,14 @@ type AddressService struct {
 
 func NewAddressService(repo repo.Repo,
 	logger *log.Logger,
+	cfg *config.MessageServiceConfig,
 	sps *SharedParamsService,
 	walletClient *gateway.IWalletCli,
 	nodeClient *NodeClient) *AddressService {
 	addressService := &AddressService{
 		repo: repo,
 		log:  logger,
+		cfg:  cfg,
 
 		sps:          sps,
 		nodeClient:   nodeClient,
@@ -69,6 +73,13 @@ func (addressService *AddressService) UpdateNonce(ctx context.Context, addr addr
 }
 
 func (addressService *AddressService) GetAddress(ctx context.Context, addr address.Address) (*types.Address, error) {
+	inScope, err := addressService.addressInScope(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if !inScope {
+		return nil, xerrors.Errorf("address %s %w", addr, gorm.ErrRecordNotFound)
+	}
 	return addressService.repo.AddressRepo().GetAddress(ctx, addr)
 }
 
@@ -78,11 +89,33 @@ func (addressService *AddressService) WalletHas(ctx context.Context, addr addres
 }
 
 func (addressService *AddressService) HasAddress(ctx context.Context, addr address.Address) (bool, error) {
+	inScope, err := addressService.addressInScope(ctx, addr)
+	if err != nil || !inScope {
+		return false, err
+	}
 	return addressService.repo.AddressRepo().HasAddress(ctx, addr)
 }
 
 func (addressService *AddressService) ListAddress(ctx context.Context) ([]*types.Address, error) {
-	return addressService.repo.AddressRepo().ListAddress(ctx)
+	addrs, err := addressService.repo.AddressRepo().ListAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := scopedAccountFromContext(ctx); !ok {
+		return addrs, nil
+	}
+
+	result := make([]*types.Address, 0, len(addrs))
+	for _, addr := range addrs {
+		inScope, err := addressService.addressInScope(ctx, addr.Addr)
+		if err != nil {
+			return nil, err
+		}
+		if inScope {
+			result = append(result, addr)
+		}
+	}
+	return result, nil
 }
 
 func (addressService *AddressService) DeleteAddress(ctx context.Context, addr address.Address) (address.Address, error) {
@@ -116,6 +149,26 @@ func (addressService *AddressService) SetSelectMsgNum(ctx context.Context, addr
 	return addr, nil
 }
 
+// SetWeight set the weight of address, addresses share the max sign per epoch of shared params by weight
+func (addressService *AddressService) SetWeight(ctx context.Context, addr address.Address, weight int64) (address.Address, error) {
+	if weight < 0 {
+		return address.Undef, xerrors.Errorf("weight must not be negative, got %d", weight)
+	}
+	has, err := addressService.repo.AddressRepo().HasAddress(ctx, addr)
+	if err != nil {
+		return address.Undef, err
+	}
+	if !has {
+		return address.Undef, errAddressNotExists
+	}
+	if err := addressService.repo.AddressRepo().UpdateWeight(ctx, addr, weight); err != nil {
+		return addr, err
+	}
+	addressService.log.Infof("set weight: %s %d", addr.String(), weight)
+
+	return addr, nil
+}
+
 func (addressService *AddressService) SetFeeParams(ctx context.Context, addr address.Address, gasOverEstimation float64, maxFeeStr, maxFeeCapStr string) (address.Address, error) {
 	has, err := addressService.repo.AddressRepo().HasAddress(ctx, addr)
 	if err != nil {
@@ -148,6 +201,44 @@ func (addressService *AddressService) SetFeeParams(ctx context.Context, addr add
 	return addr, addressService.repo.AddressRepo().UpdateFeeParams(ctx, addr, gasOverEstimation, maxFee, maxFeeCap)
 }
 
+func (addressService *AddressService) SetFeeStrategy(ctx context.Context, addr address.Address, params *types.FeeStrategyParams) (address.Address, error) {
+	has, err := addressService.repo.AddressRepo().HasAddress(ctx, addr)
+	if err != nil {
+		return address.Undef, err
+	}
+	if !has {
+		return address.Undef, errAddressNotExists
+	}
+
+	if len(params.Strategy) != 0 {
+		if _, err := GetFeeStrategy(params.Strategy); err != nil {
+			return address.Undef, err
+		}
+	}
+	if params.PremiumPercentile < 0 || params.PremiumPercentile > 100 {
+		return address.Undef, xerrors.Errorf("premium percentile must between 0 and 100")
+	}
+
+	return addr, addressService.repo.AddressRepo().UpdateFeeStrategy(ctx, addr, params)
+}
+
+func (addressService *AddressService) SetBaseFeeThreshold(ctx context.Context, addr address.Address, thresholdStr string) (address.Address, error) {
+	has, err := addressService.repo.AddressRepo().HasAddress(ctx, addr)
+	if err != nil {
+		return address.Undef, err
+	}
+	if !has {
+		return address.Undef, errAddressNotExists
+	}
+
+	threshold, err := venusTypes.BigFromString(thresholdStr)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("parsing base fee threshold: %v", err)
+	}
+
+	return addr, addressService.repo.AddressRepo().UpdateBaseFeeThreshold(ctx, addr, threshold)
+}
+
 type resetAddressResult struct {
 	latestNonce uint64
 	err         error
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type AuditService struct {
+	repo repo.Repo
+	log  *log.Logger
+}
+
+func NewAuditService(repo repo.Repo, logger *log.Logger) *AuditService {
+	return &AuditService{repo: repo, log: logger}
+}
+
+// RecordAudit save the record of admin call, failure is only logged to not affect the call
+func (auditService *AuditService) RecordAudit(ctx context.Context, record *types.AuditRecord) {
+	if err := auditService.repo.AuditRepo().SaveAuditRecord(ctx, record); err != nil {
+		auditService.log.Errorf("save audit record of %s by %s failed %v", record.Method, record.Account, err)
+	}
+}
+
+func (auditService *AuditService) ListAuditRecord(ctx context.Context, params *types.AuditListParams) ([]*types.AuditRecord, error) {
+	return auditService.repo.AuditRepo().ListAuditRecord(ctx, params)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const (
+	// BackpressureReject reject pushes when backlog reach the high-water mark
+	BackpressureReject = "reject"
+	// BackpressureDefer accept pushes with low priority when backlog reach the high-water mark
+	BackpressureDefer = "defer"
+)
+
+func checkBackpressureConfig(cfg *config.MessageServiceConfig) error {
+	switch cfg.BackpressurePolicy {
+	case "":
+		cfg.BackpressurePolicy = BackpressureReject
+	case BackpressureReject, BackpressureDefer:
+	default:
+		return xerrors.Errorf("unknown backpressure policy %s, should be %s or %s", cfg.BackpressurePolicy, BackpressureReject, BackpressureDefer)
+	}
+	return nil
+}
+
+// checkBacklog reject the message or lower its priority when unfilled messages reach the high-water marks
+func (ms *MessageService) checkBacklog(msg *types.Message) error {
+	reason, err := ms.backlogExceeded(msg)
+	if err != nil || len(reason) == 0 {
+		return err
+	}
+
+	if ms.cfg.BackpressurePolicy == BackpressureDefer {
+		if msg.Meta == nil {
+			msg.Meta = &types.MsgMeta{}
+		}
+		msg.Meta.Priority = types.PriorityLow
+		ms.log.Warnf("%s, defer message %s", reason, msg.ID)
+		return nil
+	}
+	return xerrors.Errorf("%w: %s", types.ErrBacklogFull, reason)
+}
+
+func (ms *MessageService) backlogExceeded(msg *types.Message) (string, error) {
+	if ms.cfg.AddrHighWaterMark > 0 {
+		count, err := ms.repo.MessageRepo().CountUnChainMessageByAddress(msg.From)
+		if err != nil {
+			return "", xerrors.Errorf("count unfilled message of %s failed %v", msg.From, err)
+		}
+		if uint64(count) >= ms.cfg.AddrHighWaterMark {
+			return fmt.Sprintf("address %s has %d unfilled messages, high-water mark %d", msg.From, count, ms.cfg.AddrHighWaterMark), nil
+		}
+	}
+
+	if ms.cfg.TotalHighWaterMark > 0 {
+		count, err := ms.repo.MessageRepo().CountUnChainMessage()
+		if err != nil {
+			return "", xerrors.Errorf("count unfilled message failed %v", err)
+		}
+		if uint64(count) >= ms.cfg.TotalHighWaterMark {
+			return fmt.Sprintf("%d unfilled messages in total, high-water mark %d", count, ms.cfg.TotalHighWaterMark), nil
+		}
+	}
+
+	return "", nil
+}
+
+// GetQueueDepth return the number of unfilled and filled messages of each address and in total
+func (ms *MessageService) GetQueueDepth(ctx context.Context) (*types.QueueDepth, error) {
+	unFilled, err := ms.repo.MessageRepo().CountMessageGroupByAddress(types.UnFillMsg)
+	if err != nil {
+		return nil, err
+	}
+	filled, err := ms.repo.MessageRepo().CountMessageGroupByAddress(types.FillMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := &types.QueueDepth{
+		AddrHighWaterMark:  ms.cfg.AddrHighWaterMark,
+		TotalHighWaterMark: ms.cfg.TotalHighWaterMark,
+	}
+	addrs := make(map[address.Address]*types.AddrQueueDepth)
+	for addr, count := range unFilled {
+		addrs[addr] = &types.AddrQueueDepth{Addr: addr, UnFilled: count}
+		depth.UnFilled += count
+	}
+	for addr, count := range filled {
+		addrDepth, ok := addrs[addr]
+		if !ok {
+			addrDepth = &types.AddrQueueDepth{Addr: addr}
+			addrs[addr] = addrDepth
+		}
+		addrDepth.Filled = count
+		depth.Filled += count
+	}
+	for _, addrDepth := range addrs {
+		depth.Addrs = append(depth.Addrs, addrDepth)
+	}
+	sort.Slice(depth.Addrs, func(i, j int) bool {
+		if depth.Addrs[i].UnFilled != depth.Addrs[j].UnFilled {
+			return depth.Addrs[i].UnFilled > depth.Addrs[j].UnFilled
+		}
+		return depth.Addrs[i].Addr.String() < depth.Addrs[j].Addr.String()
+	})
+
+	return depth, nil
+}
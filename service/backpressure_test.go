@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestBackpressure(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "backpressure.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("backpressure.db"))
+		assert.NoError(t, os.Remove("backpressure.db-shm"))
+		assert.NoError(t, os.Remove("backpressure.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	msgs := models.NewMessages(3)
+	for _, msg := range msgs {
+		msg.From = msgs[0].From
+		assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+	}
+
+	cfg := &config.MessageServiceConfig{AddrHighWaterMark: 3}
+	assert.NoError(t, checkBackpressureConfig(cfg))
+	assert.Equal(t, BackpressureReject, cfg.BackpressurePolicy)
+	assert.Error(t, checkBackpressureConfig(&config.MessageServiceConfig{BackpressurePolicy: "unknown"}))
+
+	ms := &MessageService{repo: db, log: log.New(), cfg: cfg}
+
+	// other address not affected
+	assert.NoError(t, ms.checkBacklog(models.NewMessage()))
+
+	msg := models.NewMessage()
+	msg.From = msgs[0].From
+	err = ms.checkBacklog(msg)
+	assert.True(t, types.IsBacklogFull(err))
+
+	cfg.BackpressurePolicy = BackpressureDefer
+	assert.NoError(t, ms.checkBacklog(msg))
+	assert.Equal(t, types.PriorityLow, msg.Meta.Priority)
+
+	cfg.AddrHighWaterMark = 0
+	cfg.TotalHighWaterMark = 3
+	cfg.BackpressurePolicy = BackpressureReject
+	err = ms.checkBacklog(models.NewMessage())
+	assert.True(t, types.IsBacklogFull(err))
+
+	depth, err := ms.GetQueueDepth(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), depth.UnFilled)
+	assert.Len(t, depth.Addrs, 1)
+	assert.Equal(t, msgs[0].From, depth.Addrs[0].Addr)
+	assert.Equal(t, int64(3), depth.Addrs[0].UnFilled)
+}
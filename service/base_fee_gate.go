@@ -0,0 +1,111 @@
+package service
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// baseFeeGate records the parent base fee of current head and the messages held by it,
+// non-urgent messages are not signed while the base fee above the threshold of address
+type baseFeeGate struct {
+	lk      sync.RWMutex
+	height  abi.ChainEpoch
+	baseFee big.Int
+	// addresses listed messages in the latest selection round
+	addrs map[address.Address]*types.AddressBaseFeeGate
+	// addresses listed messages in the selection round going on
+	roundAddrs map[address.Address]*types.AddressBaseFeeGate
+}
+
+func newBaseFeeGate() *baseFeeGate {
+	return &baseFeeGate{
+		baseFee:    big.Zero(),
+		addrs:      make(map[address.Address]*types.AddressBaseFeeGate),
+		roundAddrs: make(map[address.Address]*types.AddressBaseFeeGate),
+	}
+}
+
+// setHead record the base fee of the new head, which may be lower than the previous one after a reorg
+func (gate *baseFeeGate) setHead(ts *venusTypes.TipSet) {
+	gate.lk.Lock()
+	defer gate.lk.Unlock()
+
+	gate.height = ts.Height()
+	gate.baseFee = ts.Blocks()[0].ParentBaseFee
+}
+
+// threshold return the threshold of address, fallback to the global one
+func (gate *baseFeeGate) threshold(addr *types.Address, global *types.SharedParams) big.Int {
+	if !addr.BaseFeeThreshold.NilOrZero() {
+		return addr.BaseFeeThreshold
+	}
+	if global != nil && !global.BaseFeeThreshold.NilOrZero() {
+		return global.BaseFeeThreshold
+	}
+	return big.Zero()
+}
+
+func (gate *baseFeeGate) gated(baseFee, threshold big.Int) bool {
+	return !threshold.NilOrZero() && baseFee.GreaterThan(threshold)
+}
+
+// startRound forget the addresses set in the previous unfinished round
+func (gate *baseFeeGate) startRound() {
+	gate.lk.Lock()
+	defer gate.lk.Unlock()
+
+	gate.roundAddrs = make(map[address.Address]*types.AddressBaseFeeGate)
+}
+
+// finishRound replace the addresses with the ones set in this round, addresses removed or not listing messages
+// in this round are dropped
+func (gate *baseFeeGate) finishRound() {
+	gate.lk.Lock()
+	defer gate.lk.Unlock()
+
+	gate.addrs = gate.roundAddrs
+	gate.roundAddrs = make(map[address.Address]*types.AddressBaseFeeGate)
+}
+
+func (gate *baseFeeGate) setAddress(addr address.Address, threshold big.Int, gated bool, heldCount int64) {
+	gate.lk.Lock()
+	defer gate.lk.Unlock()
+
+	gate.roundAddrs[addr] = &types.AddressBaseFeeGate{
+		Addr:      addr,
+		Threshold: threshold,
+		Gated:     gated,
+		HeldCount: heldCount,
+	}
+}
+
+func (gate *baseFeeGate) info(global *types.SharedParams) *types.BaseFeeGate {
+	gate.lk.RLock()
+	defer gate.lk.RUnlock()
+
+	info := &types.BaseFeeGate{
+		Height:    gate.height,
+		BaseFee:   gate.baseFee,
+		Threshold: big.Zero(),
+		Addresses: make([]*types.AddressBaseFeeGate, 0, len(gate.addrs)),
+	}
+	if global != nil && !global.BaseFeeThreshold.Nil() {
+		info.Threshold = global.BaseFeeThreshold
+	}
+	for _, addr := range gate.addrs {
+		tmp := *addr
+		info.Addresses = append(info.Addresses, &tmp)
+	}
+	sort.Slice(info.Addresses, func(i, j int) bool {
+		return info.Addresses[i].Addr.String() < info.Addresses[j].Addr.String()
+	})
+
+	return info
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseFeeGate(t *testing.T) {
+	gate := newBaseFeeGate()
+
+	// the lower head after a reorg is taken
+	gate.setHead(newMockTipSet(t, 10))
+	gate.setHead(newMockTipSet(t, 9))
+	assert.Equal(t, abi.ChainEpoch(9), gate.info(nil).Height)
+
+	addr1, _ := address.NewIDAddress(1001)
+	addr2, _ := address.NewIDAddress(1002)
+	gate.startRound()
+	gate.setAddress(addr1, big.NewInt(100), true, 3)
+	gate.setAddress(addr2, big.NewInt(100), false, 0)
+	// addresses of the round going on are not shown
+	assert.Len(t, gate.info(nil).Addresses, 0)
+	gate.finishRound()
+	assert.Len(t, gate.info(nil).Addresses, 2)
+
+	// addr1 not listing messages in the next round is dropped
+	gate.startRound()
+	gate.setAddress(addr2, big.NewInt(100), true, 5)
+	gate.finishRound()
+	addrs := gate.info(nil).Addresses
+	assert.Len(t, addrs, 1)
+	assert.Equal(t, addr2, addrs[0].Addr)
+	assert.True(t, addrs[0].Gated)
+	assert.Equal(t, int64(5), addrs[0].HeldCount)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// DefaultNodeName is the node name of results pushed to the chain node messager connects to
+const DefaultNodeName = "default"
+
+// newBroadcastResult message already in mpool of node is treated as accepted
+func newBroadcastResult(msg *venusTypes.SignedMessage, node string, err error) *types.BroadcastResult {
+	result := &types.BroadcastResult{SignedCid: msg.Cid(), Node: node, Accepted: true}
+	if err != nil && !strings.Contains(err.Error(), errAlreadyInMpool.Error()) {
+		result.Accepted = false
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (ms *MessageService) saveBroadcastResults(results []*types.BroadcastResult) {
+	if err := ms.repo.BroadcastResultRepo().SaveBroadcastResults(results); err != nil {
+		ms.log.Errorf("save broadcast results failed %v", err)
+	}
+}
+
+// ListBroadcastResult return the result of message pushed to each node
+func (ms *MessageService) ListBroadcastResult(ctx context.Context, signedCid cid.Cid) ([]*types.BroadcastResult, error) {
+	if _, err := ms.GetMessageBySignedCid(ctx, signedCid); err != nil {
+		return nil, err
+	}
+	return ms.repo.BroadcastResultRepo().ListBroadcastResult(signedCid)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestNewBroadcastResult(t *testing.T) {
+	from, _ := address.NewIDAddress(1001)
+	to, _ := address.NewIDAddress(1002)
+	msg := &venusTypes.SignedMessage{
+		Message: venusTypes.UnsignedMessage{
+			From:       from,
+			To:         to,
+			Value:      big.Zero(),
+			GasFeeCap:  big.Zero(),
+			GasPremium: big.Zero(),
+		},
+		Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte("sig")},
+	}
+
+	r := newBroadcastResult(msg, "node1", nil)
+	assert.True(t, r.Accepted)
+	assert.Equal(t, msg.Cid(), r.SignedCid)
+	assert.Equal(t, "node1", r.Node)
+
+	r = newBroadcastResult(msg, "node1", xerrors.Errorf("push failed: %w", errAlreadyInMpool))
+	assert.True(t, r.Accepted)
+	assert.Empty(t, r.Error)
+
+	r = newBroadcastResult(msg, DefaultNodeName, xerrors.New("connection refused"))
+	assert.False(t, r.Accepted)
+	assert.Equal(t, "connection refused", r.Error)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const (
+	// EstimateRuleMedian take the median of the results
+	EstimateRuleMedian = "median"
+	// EstimateRuleMax take the max of the results
+	EstimateRuleMax = "max"
+)
+
+const estimateTimeout = time.Second * 5
+
+func checkEstimateConfig(cfg *config.MessageServiceConfig) error {
+	if len(cfg.EstimatePremiumRule) == 0 {
+		cfg.EstimatePremiumRule = EstimateRuleMedian
+	}
+	if len(cfg.EstimateGasLimitRule) == 0 {
+		cfg.EstimateGasLimitRule = EstimateRuleMax
+	}
+	for _, rule := range []string{cfg.EstimatePremiumRule, cfg.EstimateGasLimitRule} {
+		if rule != EstimateRuleMedian && rule != EstimateRuleMax {
+			return xerrors.Errorf("unknown estimate rule %s, should be %s or %s", rule, EstimateRuleMedian, EstimateRuleMax)
+		}
+	}
+	if cfg.EstimateQuorum <= 0 {
+		cfg.EstimateQuorum = 1
+	}
+	if cfg.EstimateNodeNum > 1 && cfg.EstimateQuorum > cfg.EstimateNodeNum {
+		return xerrors.Errorf("estimate quorum %d is more than estimate node num %d", cfg.EstimateQuorum, cfg.EstimateNodeNum)
+	}
+	if cfg.EstimateOutlierRatio < 0 {
+		return xerrors.Errorf("estimate outlier ratio must not be negative")
+	}
+	return nil
+}
+
+// chainClients return the connected full nodes which are healthy
+func (ns *NodeService) chainClients() []nodeClient {
+	var res []nodeClient
+	for _, nc := range ns.pool.clients(time.Now()) {
+		if nc.node.Type.HasRole(types.ChainRole) && ns.health.healthy(nc.name) {
+			res = append(res, nc)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+	return res
+}
+
+// estimators return the nodes to estimate with, the node messager connects to is the first one
+func (messageSelector *MessageSelector) estimators() []nodeClient {
+	cfg := messageSelector.cfg
+	estimators := []nodeClient{{name: DefaultNodeName, cli: messageSelector.nodeClient}}
+	if cfg.EstimateNodeNum <= 1 || messageSelector.nodeService == nil {
+		return estimators
+	}
+	// the node messager connects to may be switched to one of the full nodes, not to count it twice
+	var activeNode string
+	if messageSelector.failover != nil {
+		activeNode = messageSelector.failover.ActiveNode()
+	}
+	for _, nc := range messageSelector.nodeService.chainClients() {
+		if len(estimators) >= cfg.EstimateNodeNum {
+			break
+		}
+		if nc.name == activeNode {
+			continue
+		}
+		estimators = append(estimators, nc)
+	}
+	return estimators
+}
+
+// batchEstimateMessageGas estimate with the configured node, or with several nodes in parallel and combine the results
+// when EstimateNodeNum is more than 1, the estimation fails if there are not enough nodes for the quorum
+func (messageSelector *MessageSelector) batchEstimateMessageGas(ctx context.Context, msgs []*EstimateMessage, fromNonce uint64, tsk venusTypes.TipSetKey) ([]*EstimateResult, error) {
+	cfg := messageSelector.cfg
+	estimators := messageSelector.estimators()
+	if len(estimators) < cfg.EstimateQuorum {
+		messageSelector.log.Warnf("only %d nodes to estimate gas, less than estimate quorum %d", len(estimators), cfg.EstimateQuorum)
+	}
+	if len(estimators) == 1 && cfg.EstimateQuorum <= 1 {
+		timeOutCtx, cancel := context.WithTimeout(ctx, estimateTimeout)
+		defer cancel()
+		return messageSelector.nodeClient.GasBatchEstimateMessageGas(timeOutCtx, msgs, fromNonce, tsk)
+	}
+
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	var lastErr error
+	answers := make([][]*EstimateResult, 0, len(estimators))
+	for _, estimator := range estimators {
+		wg.Add(1)
+		go func(estimator nodeClient) {
+			defer wg.Done()
+			timeOutCtx, cancel := context.WithTimeout(ctx, estimateTimeout)
+			defer cancel()
+
+			res, err := estimator.cli.GasBatchEstimateMessageGas(timeOutCtx, msgs, fromNonce, tsk)
+			if err == nil && len(res) != len(msgs) {
+				err = xerrors.Errorf("expect %d results but got %d", len(msgs), len(res))
+			}
+			lk.Lock()
+			defer lk.Unlock()
+			if err != nil {
+				messageSelector.log.Warnf("estimate gas with node %s failed %v", estimator.name, err)
+				lastErr = err
+				return
+			}
+			answers = append(answers, res)
+		}(estimator)
+	}
+	wg.Wait()
+
+	if len(answers) == 0 {
+		return nil, lastErr
+	}
+
+	results := make([]*EstimateResult, len(msgs))
+	for i := range msgs {
+		nodeResults := make([]*EstimateResult, 0, len(answers))
+		for _, answer := range answers {
+			nodeResults = append(nodeResults, answer[i])
+		}
+		results[i] = combineEstimateResults(nodeResults, cfg)
+	}
+	return results, nil
+}
+
+// combineEstimateResults drop the outliers and combine the rest by the rules of config,
+// fails if the results left are less than the quorum
+func combineEstimateResults(results []*EstimateResult, cfg *config.MessageServiceConfig) *EstimateResult {
+	var msgs []*venusTypes.UnsignedMessage
+	var errStr string
+	for _, r := range results {
+		if len(r.Err) != 0 || r.Msg == nil {
+			errStr = r.Err
+			continue
+		}
+		msgs = append(msgs, r.Msg)
+	}
+	if len(msgs) == 0 {
+		return &EstimateResult{Err: errStr}
+	}
+
+	msgs = dropEstimateOutliers(msgs, cfg.EstimateOutlierRatio)
+	if len(msgs) < cfg.EstimateQuorum {
+		return &EstimateResult{Err: xerrors.Errorf("estimate quorum not reached, %d of %d results agree, need %d",
+			len(msgs), len(results), cfg.EstimateQuorum).Error()}
+	}
+
+	premiums := make([]big.Int, 0, len(msgs))
+	feeCaps := make([]big.Int, 0, len(msgs))
+	limits := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		premiums = append(premiums, msg.GasPremium)
+		feeCaps = append(feeCaps, msg.GasFeeCap)
+		limits = append(limits, msg.GasLimit)
+	}
+
+	combined := *msgs[0]
+	combined.GasPremium = pickBig(premiums, cfg.EstimatePremiumRule)
+	combined.GasFeeCap = pickBig(feeCaps, cfg.EstimatePremiumRule)
+	combined.GasLimit = pickInt64(limits, cfg.EstimateGasLimitRule)
+	// fee cap can not be less than premium
+	if combined.GasFeeCap.LessThan(combined.GasPremium) {
+		combined.GasFeeCap = combined.GasPremium
+	}
+	return &EstimateResult{Msg: &combined}
+}
+
+// dropEstimateOutliers drop the results whose gas premium or gas limit deviate from the median more than ratio
+func dropEstimateOutliers(msgs []*venusTypes.UnsignedMessage, ratio float64) []*venusTypes.UnsignedMessage {
+	if ratio <= 0 || len(msgs) < 3 {
+		return msgs
+	}
+
+	premiums := make([]big.Int, 0, len(msgs))
+	limits := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		premiums = append(premiums, msg.GasPremium)
+		limits = append(limits, msg.GasLimit)
+	}
+	medianPremium := pickBig(premiums, EstimateRuleMedian)
+	medianLimit := pickInt64(limits, EstimateRuleMedian)
+
+	// compare in per mille to keep big int
+	permille := big.NewInt(int64(ratio * 1000))
+	maxPremiumDiff := big.Div(big.Mul(medianPremium, permille), big.NewInt(1000))
+	maxLimitDiff := float64(medianLimit) * ratio
+
+	res := make([]*venusTypes.UnsignedMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		premiumDiff := big.Sub(msg.GasPremium, medianPremium).Abs()
+		limitDiff := float64(msg.GasLimit - medianLimit)
+		if limitDiff < 0 {
+			limitDiff = -limitDiff
+		}
+		// premiums can't be compared by ratio to zero, nodes estimate zero premium without enough messages in mpool
+		if (!medianPremium.IsZero() && premiumDiff.GreaterThan(maxPremiumDiff)) || limitDiff > maxLimitDiff {
+			continue
+		}
+		res = append(res, msg)
+	}
+	return res
+}
+
+// pickBig return the max or the median of values, the upper one is taken for the median of even values
+func pickBig(values []big.Int, rule string) big.Int {
+	sorted := make([]big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	if rule == EstimateRuleMax {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[len(sorted)/2]
+}
+
+func pickInt64(values []int64, rule string) int64 {
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	if rule == EstimateRuleMax {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[len(sorted)/2]
+}
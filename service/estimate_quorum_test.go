@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestCombineEstimateResults(t *testing.T) {
+	result := func(premium, feeCap, limit int64) *EstimateResult {
+		return &EstimateResult{Msg: &venusTypes.UnsignedMessage{
+			GasPremium: big.NewInt(premium),
+			GasFeeCap:  big.NewInt(feeCap),
+			GasLimit:   limit,
+		}}
+	}
+	cfg := &config.MessageServiceConfig{EstimateNodeNum: 3, EstimateQuorum: 2, EstimateOutlierRatio: 0.5}
+	assert.NoError(t, checkEstimateConfig(cfg))
+	assert.Equal(t, EstimateRuleMedian, cfg.EstimatePremiumRule)
+	assert.Equal(t, EstimateRuleMax, cfg.EstimateGasLimitRule)
+
+	t.Run("median premium and max gas limit", func(t *testing.T) {
+		r := combineEstimateResults([]*EstimateResult{
+			result(100, 1000, 10000),
+			result(120, 1200, 11000),
+			result(110, 1100, 12000),
+		}, cfg)
+		assert.Empty(t, r.Err)
+		assert.Equal(t, big.NewInt(110), r.Msg.GasPremium)
+		assert.Equal(t, big.NewInt(1100), r.Msg.GasFeeCap)
+		assert.Equal(t, int64(12000), r.Msg.GasLimit)
+	})
+
+	t.Run("drop outliers", func(t *testing.T) {
+		r := combineEstimateResults([]*EstimateResult{
+			result(100, 1000, 10000),
+			result(10000, 100000, 11000),
+			result(110, 1100, 100000),
+			result(105, 1050, 10500),
+		}, cfg)
+		assert.Empty(t, r.Err)
+		// 10000 premium and 100000 gas limit are dropped, upper median of 100 and 105 is taken
+		assert.Equal(t, big.NewInt(105), r.Msg.GasPremium)
+		assert.Equal(t, int64(10500), r.Msg.GasLimit)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		r := combineEstimateResults([]*EstimateResult{
+			result(100, 1000, 10000),
+			{Err: "actor not found"},
+			{Err: "actor not found"},
+		}, cfg)
+		assert.Nil(t, r.Msg)
+		assert.Contains(t, r.Err, "quorum not reached")
+
+		r = combineEstimateResults([]*EstimateResult{{Err: "actor not found"}, {Err: "actor not found"}}, cfg)
+		assert.Equal(t, "actor not found", r.Err)
+	})
+
+	t.Run("zero median premium", func(t *testing.T) {
+		r := combineEstimateResults([]*EstimateResult{
+			result(0, 1000, 10000),
+			result(0, 1000, 10000),
+			result(100, 1000, 10000),
+		}, cfg)
+		assert.Empty(t, r.Err)
+		assert.Equal(t, big.Zero(), r.Msg.GasPremium)
+		assert.Len(t, dropEstimateOutliers([]*venusTypes.UnsignedMessage{
+			result(0, 1000, 10000).Msg,
+			result(0, 1000, 10000).Msg,
+			result(100, 1000, 10000).Msg,
+		}, 0.5), 3)
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		assert.Error(t, checkEstimateConfig(&config.MessageServiceConfig{EstimatePremiumRule: "avg"}))
+		assert.Error(t, checkEstimateConfig(&config.MessageServiceConfig{EstimateNodeNum: 2, EstimateQuorum: 3}))
+	})
+}
+
+func TestBatchEstimateMessageGas(t *testing.T) {
+	var lk sync.Mutex
+	calls := map[string]int{}
+	estimator := func(name string) *NodeClient {
+		return &NodeClient{
+			GasBatchEstimateMessageGas: func(ctx context.Context, msgs []*EstimateMessage, fromNonce uint64, tsk venusTypes.TipSetKey) ([]*EstimateResult, error) {
+				lk.Lock()
+				defer lk.Unlock()
+				calls[name]++
+				res := make([]*EstimateResult, len(msgs))
+				for i, msg := range msgs {
+					estimated := *msg.Msg
+					estimated.GasPremium = big.NewInt(100)
+					estimated.GasFeeCap = big.NewInt(1000)
+					estimated.GasLimit = 10000
+					res[i] = &EstimateResult{Msg: &estimated}
+				}
+				return res, nil
+			},
+		}
+	}
+	dial := func(ctx context.Context, cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error) {
+		return estimator(cfg.Url), func() {}, nil
+	}
+	nodeService := &NodeService{log: log.New(), health: newNodeHealthMonitor(), pool: newNodeClientPool(log.New(), dial)}
+	defer nodeService.pool.close()
+	nodeService.pool.refresh([]*types.Node{
+		{Name: "a", URL: "a", Type: types.FullNode},
+		{Name: "b", URL: "b", Type: types.FullNode},
+		{Name: "c", URL: "c", Type: types.FullNode},
+	})
+
+	cfg := &config.MessageServiceConfig{EstimateNodeNum: 3, EstimateQuorum: 3}
+	assert.NoError(t, checkEstimateConfig(cfg))
+	messageSelector := &MessageSelector{
+		log:         log.New(),
+		cfg:         cfg,
+		nodeClient:  estimator(DefaultNodeName),
+		nodeService: nodeService,
+		// switched to node a, the default client calls it
+		failover: &NodeFailover{active: &nodeConn{name: "a"}},
+	}
+	msgs := []*EstimateMessage{{Msg: &venusTypes.Message{}}}
+
+	res, err := messageSelector.batchEstimateMessageGas(context.Background(), msgs, 0, venusTypes.EmptyTSK)
+	assert.NoError(t, err)
+	assert.Empty(t, res[0].Err)
+	assert.Equal(t, map[string]int{DefaultNodeName: 1, "b": 1, "c": 1}, calls)
+
+	// quorum can't be reached by the default node only
+	messageSelector.nodeService = nil
+	res, err = messageSelector.batchEstimateMessageGas(context.Background(), msgs, 0, venusTypes.EmptyTSK)
+	assert.NoError(t, err)
+	assert.Nil(t, res[0].Msg)
+	assert.Contains(t, res[0].Err, "quorum not reached")
+}
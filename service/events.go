@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/venus-messager/log"
@@ -10,17 +12,51 @@ import (
 	"github.com/filecoin-project/venus/pkg/types"
 )
 
+const (
+	// max number of head changes remembered to deduplicate events from multiple nodes
+	headDedupSize = 1000
+	// resubscribe if the configured node sends no head change in this period
+	headStallTimeout = time.Minute * 5
+)
+
 type NodeEvents struct {
 	client     *NodeClient
 	log        *log.Logger
 	msgService *MessageService
+	failover   *NodeFailover
+}
+
+type headSource struct {
+	name   string
+	notifs <-chan []*chain.HeadChange
+}
+
+type sourcedHeadChanges struct {
+	source  string
+	changes []*chain.HeadChange
 }
 
 func (nd *NodeEvents) listenHeadChangesOnce(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if nd.failover != nil {
+		// resubscribe from the new node, and do reconnect check with its head
+		switched := nd.failover.switched()
+		go func() {
+			select {
+			case <-switched:
+				nd.log.Infof("node switched to %s, resubscribe head changes", nd.failover.ActiveNode())
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	notifs, err := nd.client.ChainNotify(ctx)
 	if err != nil {
 		return err
 	}
+	var head *types.TipSet
 	select {
 	case noti := <-notifs:
 		if len(noti) != 1 {
@@ -34,15 +70,53 @@ func (nd *NodeEvents) listenHeadChangesOnce(ctx context.Context) error {
 		if err := nd.msgService.ReconnectCheck(ctx, noti[0].Val); err != nil {
 			return xerrors.Errorf("reconnect check error: %v", err)
 		}
+		head = noti[0].Val
 	case <-ctx.Done():
 		return ctx.Err()
 	}
 
-	for notif := range notifs {
+	// the configured node decides when to resubscribe, the extra sources only bring events earlier
+	merged := make(chan sourcedHeadChanges)
+	primaryDone := make(chan struct{})
+	go func() {
+		defer close(primaryDone)
+		nd.forwardHeadChanges(ctx, headSource{name: DefaultNodeName, notifs: notifs}, merged)
+	}()
+	for _, src := range nd.subscribeExtraHeadSources(ctx) {
+		go nd.forwardHeadChanges(ctx, src, merged)
+	}
+
+	filter := newHeadFilter(head, headDedupSize)
+	stall := time.NewTimer(headStallTimeout)
+	defer stall.Stop()
+	for {
+		var notif sourcedHeadChanges
+		select {
+		case notif = <-merged:
+		case <-primaryDone:
+			return nil
+		case <-stall.C:
+			return xerrors.Errorf("no head change from node in %v", headStallTimeout)
+		case <-ctx.Done():
+			return nil
+		}
+
+		primary := notif.source == DefaultNodeName
+		if primary {
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(headStallTimeout)
+		}
+		changes := filter.filter(primary, notif.changes)
+		if lag := filter.primaryLag(); lag > nodeMaxLag {
+			return xerrors.Errorf("node lags behind extra head sources %d epochs", lag)
+		}
+
 		var apply []*types.TipSet
 		var revert []*types.TipSet
 
-		for _, change := range notif {
+		for _, change := range changes {
 			switch change.Type {
 			case chain.HCApply:
 				apply = append(apply, change.Val)
@@ -50,10 +124,163 @@ func (nd *NodeEvents) listenHeadChangesOnce(ctx context.Context) error {
 				revert = append(revert, change.Val)
 			}
 		}
+		if len(apply) == 0 && len(revert) == 0 {
+			continue
+		}
 
 		if err := nd.msgService.ProcessNewHead(ctx, apply, revert); err != nil {
 			return xerrors.Errorf("process new head error: %v", err)
 		}
 	}
-	return nil
+}
+
+// subscribeExtraHeadSources subscribe head changes of healthy full nodes in database, see ExtraHeadSources of config
+func (nd *NodeEvents) subscribeExtraHeadSources(ctx context.Context) []headSource {
+	num := nd.msgService.cfg.ExtraHeadSources
+	if num <= 0 || nd.msgService.nodeService == nil {
+		return nil
+	}
+
+	var sources []headSource
+	for _, nc := range nd.msgService.nodeService.chainClients() {
+		if len(sources) >= num {
+			break
+		}
+		notifs, err := nc.cli.ChainNotify(ctx)
+		if err != nil {
+			nd.log.Warnf("subscribe head changes of node %s failed %v", nc.name, err)
+			continue
+		}
+		nd.log.Infof("subscribe head changes of node %s", nc.name)
+		sources = append(sources, headSource{name: nc.name, notifs: notifs})
+	}
+	return sources
+}
+
+// forwardHeadChanges send apply and revert events of source to out until source closed
+func (nd *NodeEvents) forwardHeadChanges(ctx context.Context, src headSource, out chan<- sourcedHeadChanges) {
+	for notif := range src.notifs {
+		changes := make([]*chain.HeadChange, 0, len(notif))
+		for _, change := range notif {
+			if change.Type != chain.HCCurrent {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		select {
+		case out <- sourcedHeadChanges{source: src.name, changes: changes}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	nd.log.Infof("head changes of node %s closed", src.name)
+}
+
+// headFilter deduplicate head changes from multiple nodes. Changes of the configured node are processed as they are,
+// changes of extra sources are only accepted when they extend the current head, so that a lagging source can't
+// apply a tipset reverted already, reorgs are left to the configured node
+type headFilter struct {
+	dedup *headDedup
+	head  *types.TipSet
+	// height of the latest tipset applied by the configured node
+	primaryHeight abi.ChainEpoch
+}
+
+func newHeadFilter(head *types.TipSet, size int) *headFilter {
+	return &headFilter{dedup: newHeadDedup(size), head: head, primaryHeight: head.Height()}
+}
+
+// filter return the changes to process, and update the current head
+func (f *headFilter) filter(primary bool, changes []*chain.HeadChange) []*chain.HeadChange {
+	highest := highestApply(changes)
+	if primary {
+		if highest != nil && highest.Height() > f.primaryHeight {
+			f.primaryHeight = highest.Height()
+		}
+	} else if !extendsHead(f.head, changes) {
+		return nil
+	}
+
+	res := f.dedup.filter(changes)
+	if applied := highestApply(res); applied != nil {
+		f.head = applied
+	}
+	return res
+}
+
+// primaryLag return how many epochs the configured node lags behind the head brought by extra sources
+func (f *headFilter) primaryLag() int64 {
+	return int64(f.head.Height() - f.primaryHeight)
+}
+
+// extendsHead return true if the changes only apply tipsets on top of head
+func extendsHead(head *types.TipSet, changes []*chain.HeadChange) bool {
+	var lowest *types.TipSet
+	for _, change := range changes {
+		if change.Type != chain.HCApply {
+			return false
+		}
+		if lowest == nil || change.Val.Height() < lowest.Height() {
+			lowest = change.Val
+		}
+	}
+	return lowest != nil && lowest.Parents().Equals(head.Key())
+}
+
+func highestApply(changes []*chain.HeadChange) *types.TipSet {
+	var highest *types.TipSet
+	for _, change := range changes {
+		if change.Type == chain.HCApply && (highest == nil || change.Val.Height() > highest.Height()) {
+			highest = change.Val
+		}
+	}
+	return highest
+}
+
+// headDedup remember the recent head changes by type and tipset key
+type headDedup struct {
+	size  int
+	seq   uint64
+	seen  map[string]uint64
+	order []string
+}
+
+func newHeadDedup(size int) *headDedup {
+	return &headDedup{size: size, seen: make(map[string]uint64)}
+}
+
+// filter return the changes not seen before, a tipset reverted can be applied again and vice versa
+func (d *headDedup) filter(changes []*chain.HeadChange) []*chain.HeadChange {
+	var res []*chain.HeadChange
+	for _, change := range changes {
+		key := change.Val.Key().String()
+		if _, ok := d.seen[change.Type+key]; ok {
+			continue
+		}
+		switch change.Type {
+		case chain.HCApply:
+			delete(d.seen, chain.HCRevert+key)
+		case chain.HCRevert:
+			delete(d.seen, chain.HCApply+key)
+		}
+		d.add(change.Type + key)
+		res = append(res, change)
+	}
+	return res
+}
+
+func (d *headDedup) add(key string) {
+	d.seq++
+	d.seen[key] = d.seq
+	d.order = append(d.order, key)
+	for len(d.order) > d.size {
+		oldest := d.order[0]
+		d.order = d.order[1:]
+		// the key may be added again later, only remove it when it is the oldest one
+		if seq, ok := d.seen[oldest]; ok && seq == d.seq-uint64(len(d.order)) {
+			delete(d.seen, oldest)
+		}
+	}
 }
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadDedup(t *testing.T) {
+	ts1 := newMockTipSet(t, 1)
+	ts2 := newMockTipSet(t, 2)
+	ts3 := newMockTipSet(t, 3)
+	apply := func(heights ...abi.ChainEpoch) []abi.ChainEpoch { return heights }
+	heights := func(changes []*chain.HeadChange) []abi.ChainEpoch {
+		var res []abi.ChainEpoch
+		for _, c := range changes {
+			res = append(res, c.Val.Height())
+		}
+		return res
+	}
+
+	dedup := newHeadDedup(3)
+	assert.Equal(t, apply(1, 2), heights(dedup.filter([]*chain.HeadChange{
+		{Type: chain.HCApply, Val: ts1},
+		{Type: chain.HCApply, Val: ts2},
+	})))
+	// same events from another node
+	assert.Empty(t, dedup.filter([]*chain.HeadChange{{Type: chain.HCApply, Val: ts2}}))
+	assert.Equal(t, apply(3), heights(dedup.filter([]*chain.HeadChange{
+		{Type: chain.HCApply, Val: ts2},
+		{Type: chain.HCApply, Val: ts3},
+	})))
+
+	// revert and apply again
+	assert.Equal(t, apply(3), heights(dedup.filter([]*chain.HeadChange{{Type: chain.HCRevert, Val: ts3}})))
+	assert.Empty(t, dedup.filter([]*chain.HeadChange{{Type: chain.HCRevert, Val: ts3}}))
+	assert.Equal(t, apply(3), heights(dedup.filter([]*chain.HeadChange{{Type: chain.HCApply, Val: ts3}})))
+
+	// the oldest is forgotten when full
+	assert.LessOrEqual(t, len(dedup.seen), 3)
+	assert.Equal(t, apply(1), heights(dedup.filter([]*chain.HeadChange{{Type: chain.HCApply, Val: ts1}})))
+}
+
+// newMockChildTipSet return a tipset on top of parent, tipsets of different forks have different keys
+func newMockChildTipSet(t *testing.T, parent *types.TipSet, fork string) *types.TipSet {
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	c, err := abi.CidBuilder.Sum([]byte("mock" + fork))
+	require.NoError(t, err)
+	ts, err := types.NewTipSet(&types.BlockHeader{
+		Miner:                 miner,
+		Parents:               parent.Key(),
+		Height:                parent.Height() + 1,
+		ParentWeight:          big.Zero(),
+		ParentBaseFee:         big.Zero(),
+		ParentStateRoot:       c,
+		ParentMessageReceipts: c,
+		Messages:              c,
+	})
+	require.NoError(t, err)
+	return ts
+}
+
+func TestHeadFilter(t *testing.T) {
+	ts1 := newMockTipSet(t, 1)
+	ts2 := newMockChildTipSet(t, ts1, "")
+	ts3 := newMockChildTipSet(t, ts2, "")
+	ts3b := newMockChildTipSet(t, ts2, "b")
+
+	f := newHeadFilter(ts1, 10)
+	// extra source brings the event earlier, the same event of configured node is skipped
+	assert.Len(t, f.filter(false, []*chain.HeadChange{{Type: chain.HCApply, Val: ts2}}), 1)
+	assert.Empty(t, f.filter(true, []*chain.HeadChange{{Type: chain.HCApply, Val: ts2}}))
+	assert.Len(t, f.filter(false, []*chain.HeadChange{{Type: chain.HCApply, Val: ts3}}), 1)
+
+	// reorg of configured node
+	assert.Len(t, f.filter(true, []*chain.HeadChange{
+		{Type: chain.HCRevert, Val: ts3},
+		{Type: chain.HCApply, Val: ts3b},
+	}), 2)
+	assert.Equal(t, ts3b.Key(), f.head.Key())
+
+	// lagging source can't apply the tipset reverted, and reverts of extra sources are ignored
+	assert.Empty(t, f.filter(false, []*chain.HeadChange{{Type: chain.HCApply, Val: ts3}}))
+	assert.Empty(t, f.filter(false, []*chain.HeadChange{{Type: chain.HCRevert, Val: ts3b}}))
+	assert.Equal(t, ts3b.Key(), f.head.Key())
+	assert.Equal(t, int64(0), f.primaryLag())
+
+	// configured node stalls while extra sources go on
+	head := ts3b
+	for i := 0; i <= nodeMaxLag; i++ {
+		head = newMockChildTipSet(t, head, "")
+		assert.Len(t, f.filter(false, []*chain.HeadChange{{Type: chain.HCApply, Val: head}}), 1)
+	}
+	assert.Equal(t, int64(nodeMaxLag+1), f.primaryLag())
+}
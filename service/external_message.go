@@ -0,0 +1,61 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func newExternalMessage(msg pendingMessage, tsKey venusTypes.TipSetKey, conflictMsgID string) *types.ExternalMessage {
+	return &types.ExternalMessage{
+		Cid:           msg.cid,
+		From:          msg.msg.From,
+		Nonce:         msg.msg.Nonce,
+		Height:        int64(msg.height),
+		TipSetKey:     tsKey,
+		Receipt:       msg.receipt,
+		ConflictMsgID: conflictMsgID,
+	}
+}
+
+// isSameCall return true if the messages only differ in gas, that is one replaced the other
+func isSameCall(a, b *venusTypes.UnsignedMessage) bool {
+	return a.To == b.To && a.Method == b.Method && a.Value.Equals(b.Value) && bytes.Equal(a.Params, b.Params)
+}
+
+// requeueMessage reset the message to UnFillMsg, so that it is selected again with a fresh nonce
+func requeueMessage(msg *types.Message, reason string) {
+	msg.State = types.UnFillMsg
+	msg.Nonce = 0
+	msg.UnsignedCid = nil
+	msg.SignedCid = nil
+	msg.Signature = nil
+	msg.SignedAt = nil
+	msg.Height = 0
+	msg.TipSetKey = venusTypes.EmptyTSK
+	msg.Receipt = &venusTypes.MessageReceipt{ExitCode: -1, ReturnValue: []byte(reason)}
+}
+
+// failMessage mark the message failed, used for messages signed out of messager which can't be re-queued
+func failMessage(msg *types.Message, reason string) {
+	msg.State = types.FailedMsg
+	msg.Receipt = &venusTypes.MessageReceipt{ExitCode: -1, ReturnValue: []byte(reason)}
+}
+
+func externalConflictReason(msg pendingMessage, requeued bool) string {
+	reason := fmt.Sprintf("nonce %d taken by message %s sent out of messager", msg.msg.Nonce, msg.cid)
+	if requeued {
+		return reason + ", re-queued"
+	}
+	return reason
+}
+
+// ListExternalMessage list messages sent from managed addresses outside the messager, address.Undef means all addresses
+func (ms *MessageService) ListExternalMessage(ctx context.Context, from address.Address) ([]*types.ExternalMessage, error) {
+	return ms.repo.ExternalMessageRepo().ListExternalMessage(from)
+}
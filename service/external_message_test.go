@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venustypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestUpdateMessageStateExternal(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "external_message.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("external_message.db"))
+		assert.NoError(t, os.Remove("external_message.db-shm"))
+		assert.NoError(t, os.Remove("external_message.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	localMsg := models.NewMessage()
+	localMsg.Nonce = 5
+	localMsg.State = types.FillMsg
+	unsignedCid := localMsg.UnsignedMessage.Cid()
+	localMsg.UnsignedCid = &unsignedCid
+	localMsg.SignedCid = &unsignedCid
+	assert.NoError(t, db.MessageRepo().CreateMessage(localMsg))
+
+	// same nonce but another call
+	conflict := localMsg.UnsignedMessage
+	conflict.Value = big.Add(conflict.Value, big.NewInt(1))
+	// nonce not used by messager
+	unknown := localMsg.UnsignedMessage
+	unknown.Nonce = 6
+
+	ms := &MessageService{repo: db, log: log.New()}
+	tsKeys := map[abi.ChainEpoch]venustypes.TipSetKey{10: venustypes.EmptyTSK}
+	applyMsgs := []pendingMessage{
+		{cid: conflict.Cid(), msg: &conflict, height: 10, receipt: &venustypes.MessageReceipt{}},
+		{cid: unknown.Cid(), msg: &unknown, height: 10, receipt: &venustypes.MessageReceipt{}},
+	}
+	replaced, err := ms.updateMessageState(context.Background(), tsKeys, applyMsgs, map[cid.Cid]struct{}{}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, replaced, localMsg.ID)
+
+	requeued, err := db.MessageRepo().GetMessageByUid(localMsg.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.UnFillMsg, requeued.State)
+	assert.Nil(t, requeued.SignedCid)
+
+	externals, err := ms.ListExternalMessage(context.Background(), localMsg.From)
+	assert.NoError(t, err)
+	assert.Len(t, externals, 2)
+	conflictIDs := make(map[cid.Cid]string)
+	for _, msg := range externals {
+		conflictIDs[msg.Cid] = msg.ConflictMsgID
+	}
+	assert.Equal(t, localMsg.ID, conflictIDs[conflict.Cid()])
+	assert.Equal(t, "", conflictIDs[unknown.Cid()])
+
+	// message signed out of messager can't be re-queued
+	importedMsg := models.NewMessage()
+	importedMsg.From = localMsg.From
+	importedMsg.Nonce = 7
+	importedMsg.State = types.FillMsg
+	importedMsg.WalletName = types.ExternalSignerWallet
+	importedCid := importedMsg.UnsignedMessage.Cid()
+	importedMsg.UnsignedCid = &importedCid
+	importedMsg.SignedCid = &importedCid
+	assert.NoError(t, db.MessageRepo().CreateMessage(importedMsg))
+	importedConflict := importedMsg.UnsignedMessage
+	importedConflict.Value = big.Add(importedConflict.Value, big.NewInt(1))
+	_, err = ms.updateMessageState(context.Background(), tsKeys, []pendingMessage{
+		{cid: importedConflict.Cid(), msg: &importedConflict, height: 10, receipt: &venustypes.MessageReceipt{}},
+	}, map[cid.Cid]struct{}{}, nil)
+	assert.NoError(t, err)
+	failed, err := db.MessageRepo().GetMessageByUid(importedMsg.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.FailedMsg, failed.State)
+	assert.Equal(t, uint64(7), failed.Nonce)
+
+	// the tipset is reverted
+	_, err = ms.updateMessageState(context.Background(), tsKeys, nil, map[cid.Cid]struct{}{}, []*venustypes.TipSet{newMockTipSet(t, 10)})
+	assert.NoError(t, err)
+	externals, err = ms.ListExternalMessage(context.Background(), localMsg.From)
+	assert.NoError(t, err)
+	assert.Len(t, externals, 0)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// listFairShareMessage list unfilled messages of address, and interleave the accounts pushing from it by their weights,
+// so that one account with lots of messages can not take all the selection of address
+func (messageSelector *MessageSelector) listFairShareMessage(ctx context.Context, addr address.Address, topN int) ([]*types.Message, error) {
+	users, err := messageSelector.repo.MessageRepo().ListUnChainUserByAddress(addr)
+	if err != nil {
+		return nil, xerrors.Errorf("list accounts of %s error %v", addr, err)
+	}
+	if len(users) <= 1 {
+		messageSelector.fairShare.save(addr, nil, nil)
+		msgs, err := messageSelector.repo.MessageRepo().ListUnChainMessageByAddress(addr, topN)
+		if err != nil {
+			return nil, err
+		}
+		sortByExpireEpoch(msgs)
+		return msgs, nil
+	}
+	sort.Strings(users)
+
+	weightList, err := messageSelector.repo.AccountWeightRepo().ListAccountWeight(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("list account weight error %v", err)
+	}
+	accountWeights := make(map[string]int64, len(weightList))
+	for _, w := range weightList {
+		accountWeights[w.Account] = w.Weight
+	}
+
+	queues := make([][]*types.Message, len(users))
+	weights := make([]int64, len(users))
+	for i, user := range users {
+		queues[i], err = messageSelector.repo.MessageRepo().ListUnChainMessageByAddressAndUser(addr, user, topN)
+		if err != nil {
+			return nil, xerrors.Errorf("list %s unpackage message of %s error %v", addr, user, err)
+		}
+		sortByExpireEpoch(queues[i])
+		weights[i] = types.DefaultAccountWeight
+		if w, ok := accountWeights[user]; ok && w > 0 {
+			weights[i] = w
+		}
+	}
+
+	current := messageSelector.fairShare.load(addr, users)
+	msgs := weightedRoundRobin(queues, weights, current, topN)
+	messageSelector.fairShare.save(addr, users, current)
+	return msgs, nil
+}
+
+// weightedRoundRobin take at most total messages from queues by smooth weighted round robin, current is the
+// current weights of queues which is updated, the messages in one queue keep their order
+func weightedRoundRobin(queues [][]*types.Message, weights []int64, current []int64, total int) []*types.Message {
+	result := make([]*types.Message, 0, total)
+	for len(result) < total {
+		var sum int64
+		pick := -1
+		for i, queue := range queues {
+			if len(queue) == 0 {
+				continue
+			}
+			sum += weights[i]
+			current[i] += weights[i]
+			if pick == -1 || current[i] > current[pick] {
+				pick = i
+			}
+		}
+		if pick == -1 {
+			break
+		}
+		current[pick] -= sum
+		result = append(result, queues[pick][0])
+		queues[pick] = queues[pick][1:]
+	}
+	return result
+}
+
+// fairShareCredits keep the current weights of round robin between selection rounds, otherwise the first accounts
+// always win when an address selects less messages than its accounts in one round
+type fairShareCredits struct {
+	lk sync.Mutex
+	// address -> account -> current weight
+	current map[address.Address]map[string]int64
+}
+
+func newFairShareCredits() *fairShareCredits {
+	return &fairShareCredits{current: make(map[address.Address]map[string]int64)}
+}
+
+// load return the current weights of users pushing from addr, zero for new users
+func (credits *fairShareCredits) load(addr address.Address, users []string) []int64 {
+	credits.lk.Lock()
+	defer credits.lk.Unlock()
+
+	current := make([]int64, len(users))
+	for i, user := range users {
+		current[i] = credits.current[addr][user]
+	}
+	return current
+}
+
+// save replace the current weights of addr, users without messages to push are forgotten
+func (credits *fairShareCredits) save(addr address.Address, users []string, current []int64) {
+	credits.lk.Lock()
+	defer credits.lk.Unlock()
+
+	if len(users) <= 1 {
+		delete(credits.current, addr)
+		return
+	}
+	userCurrent := make(map[string]int64, len(users))
+	for i, user := range users {
+		userCurrent[user] = current[i]
+	}
+	credits.current[addr] = userCurrent
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestWeightedRoundRobin(t *testing.T) {
+	newQueue := func(user string, count int) []*types.Message {
+		queue := make([]*types.Message, count)
+		for i := range queue {
+			queue[i] = &types.Message{ID: types.NewUUID().String(), FromUser: user}
+		}
+		return queue
+	}
+	users := func(msgs []*types.Message) []string {
+		result := make([]string, len(msgs))
+		for i, msg := range msgs {
+			result[i] = msg.FromUser
+		}
+		return result
+	}
+
+	t.Run("equal weight", func(t *testing.T) {
+		msgs := weightedRoundRobin([][]*types.Message{newQueue("a", 10), newQueue("b", 2)}, []int64{1, 1}, make([]int64, 2), 6)
+		assert.Equal(t, []string{"a", "b", "a", "b", "a", "a"}, users(msgs))
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		msgs := weightedRoundRobin([][]*types.Message{newQueue("a", 10), newQueue("b", 10)}, []int64{1, 3}, make([]int64, 2), 8)
+		assert.Equal(t, []string{"b", "a", "b", "b", "b", "a", "b", "b"}, users(msgs))
+	})
+
+	t.Run("keep order in queue", func(t *testing.T) {
+		queue := newQueue("a", 3)
+		msgs := weightedRoundRobin([][]*types.Message{queue}, []int64{1}, make([]int64, 1), 10)
+		assert.Equal(t, queue, msgs)
+	})
+
+	t.Run("take turns between rounds", func(t *testing.T) {
+		current := make([]int64, 3)
+		var rounds [][]string
+		for i := 0; i < 3; i++ {
+			queues := [][]*types.Message{newQueue("a", 10), newQueue("b", 10), newQueue("c", 10)}
+			rounds = append(rounds, users(weightedRoundRobin(queues, []int64{1, 1, 1}, current, 2)))
+		}
+		assert.Equal(t, [][]string{{"a", "b"}, {"c", "a"}, {"b", "c"}}, rounds)
+	})
+}
+
+func TestListFairShareMessage(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "fair_share.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("fair_share.db"))
+		assert.NoError(t, os.Remove("fair_share.db-shm"))
+		assert.NoError(t, os.Remove("fair_share.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	// messages of a expire later than b, but should still be interleaved
+	msgs := models.NewMessages(4)
+	expireEpochs := []abi.ChainEpoch{200, 100, 20, 10}
+	for i, msg := range msgs {
+		msg.From = msgs[0].From
+		msg.FromUser = "a"
+		if i >= 2 {
+			msg.FromUser = "b"
+		}
+		msg.Meta.ExpireEpoch = expireEpochs[i]
+		assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+	}
+
+	messageSelector := &MessageSelector{repo: db, fairShare: newFairShareCredits()}
+	selected, err := messageSelector.listFairShareMessage(context.Background(), msgs[0].From, 4)
+	assert.NoError(t, err)
+	ids := make([]string, len(selected))
+	for i, msg := range selected {
+		ids[i] = msg.ID
+	}
+	// sorted by expire epoch in each account
+	assert.Equal(t, []string{msgs[1].ID, msgs[3].ID, msgs[0].ID, msgs[2].ID}, ids)
+
+	// select one message each round, the accounts take turns
+	messageSelector.fairShare = newFairShareCredits()
+	var users []string
+	for i := 0; i < 4; i++ {
+		selected, err = messageSelector.listFairShareMessage(context.Background(), msgs[0].From, 1)
+		assert.NoError(t, err)
+		assert.Len(t, selected, 1)
+		users = append(users, selected[0].FromUser)
+	}
+	assert.Equal(t, []string{"a", "b", "a", "b"}, users)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	venustypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// newFeeHistory collect the fee market of parent messages executed in ts
+func newFeeHistory(ts *venustypes.TipSet, msgs []*venustypes.UnsignedMessage, addrs map[address.Address]struct{}) *types.FeeHistory {
+	history := &types.FeeHistory{
+		Height:        ts.Height(),
+		TipsetKey:     ts.Key().String(),
+		BaseFee:       ts.Blocks()[0].ParentBaseFee,
+		Timestamp:     ts.MinTimestamp(),
+		MedianPremium: big.Zero(),
+		PremiumPaid:   big.Zero(),
+	}
+
+	premiums := make([]big.Int, 0, len(msgs))
+	for _, msg := range msgs {
+		premiums = append(premiums, msg.GasPremium)
+		if _, ok := addrs[msg.From]; ok {
+			history.MsgCount++
+			history.GasLimit += msg.GasLimit
+			history.PremiumPaid = big.Add(history.PremiumPaid, minerTip(msg, history.BaseFee))
+		}
+	}
+	if len(premiums) > 0 {
+		sort.Slice(premiums, func(i, j int) bool {
+			return premiums[i].LessThan(premiums[j])
+		})
+		history.MedianPremium = premiums[len(premiums)/2]
+	}
+
+	return history
+}
+
+// minerTip return the premium paid to miner, premium is capped at fee cap minus base fee
+func minerTip(msg *venustypes.UnsignedMessage, baseFee big.Int) big.Int {
+	premium := big.Min(msg.GasPremium, big.Sub(msg.GasFeeCap, baseFee))
+	if premium.LessThan(big.Zero()) {
+		premium = big.Zero()
+	}
+	return big.Mul(premium, big.NewInt(msg.GasLimit))
+}
+
+func (ms *MessageService) saveFeeHistory(histories []*types.FeeHistory) {
+	for _, history := range histories {
+		if err := ms.repo.FeeHistoryRepo().SaveFeeHistory(history); err != nil {
+			ms.log.Errorf("save fee history at height %d failed %v", history.Height, err)
+		}
+	}
+}
+
+func (ms *MessageService) ListFeeHistory(ctx context.Context, start, end time.Time) ([]*types.FeeHistory, error) {
+	if end.Before(start) {
+		return nil, xerrors.Errorf("end time %v before start time %v", end, start)
+	}
+	return ms.repo.FeeHistoryRepo().ListFeeHistory(start, end)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinerTip(t *testing.T) {
+	msg := &venusTypes.UnsignedMessage{
+		GasLimit:   100,
+		GasFeeCap:  big.NewInt(1000),
+		GasPremium: big.NewInt(200),
+	}
+
+	assert.Equal(t, big.NewInt(20000), minerTip(msg, big.NewInt(500)))
+	// premium is capped at fee cap minus base fee
+	assert.Equal(t, big.NewInt(10000), minerTip(msg, big.NewInt(900)))
+	assert.Equal(t, big.Zero(), minerTip(msg, big.NewInt(1100)))
+}
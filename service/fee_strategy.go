@@ -0,0 +1,281 @@
+package service
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const (
+	NodeEstimateStrategy      = "node-estimate"
+	FixedPremiumStrategy      = "fixed-premium"
+	BaseFeeMultiplierStrategy = "basefee-multiplier"
+	PremiumPercentileStrategy = "premium-percentile"
+)
+
+const (
+	defBaseFeeMultiplier = 2.0
+	defPremiumPercentile = 50
+
+	// number of tipsets whose message premiums are kept for the percentile strategy
+	premiumHistoryLen = 10
+)
+
+// FeeStrategy decides the gas premium and fee cap of a message whose gas limit has been estimated by node
+type FeeStrategy interface {
+	Name() string
+	SetFee(msg *venusTypes.UnsignedMessage, feeCtx *FeeContext) error
+}
+
+type FeeContext struct {
+	// parent base fee of the tipset that messages selected on
+	BaseFee big.Int
+	MaxFee  big.Int
+	// premiums of messages in recent tipsets, sorted ascending
+	RecentPremiums []big.Int
+	Params         *types.FeeStrategyParams
+}
+
+var feeStrategies = map[string]FeeStrategy{
+	NodeEstimateStrategy:      &nodeEstimateFeeStrategy{},
+	FixedPremiumStrategy:      &fixedPremiumFeeStrategy{},
+	BaseFeeMultiplierStrategy: &baseFeeMultiplierFeeStrategy{},
+	PremiumPercentileStrategy: &premiumPercentileFeeStrategy{},
+}
+
+// GetFeeStrategy return the strategy of name, empty name means node-estimate
+func GetFeeStrategy(name string) (FeeStrategy, error) {
+	if len(name) == 0 {
+		name = NodeEstimateStrategy
+	}
+	strategy, ok := feeStrategies[name]
+	if !ok {
+		return nil, xerrors.Errorf("unknown fee strategy %s", name)
+	}
+	return strategy, nil
+}
+
+// nodeEstimateFeeStrategy use the premium and fee cap estimated by node
+type nodeEstimateFeeStrategy struct{}
+
+func (s *nodeEstimateFeeStrategy) Name() string {
+	return NodeEstimateStrategy
+}
+
+func (s *nodeEstimateFeeStrategy) SetFee(msg *venusTypes.UnsignedMessage, feeCtx *FeeContext) error {
+	CapGasFee(msg, feeCtx.MaxFee)
+	return nil
+}
+
+// fixedPremiumFeeStrategy always pay a fixed premium
+type fixedPremiumFeeStrategy struct{}
+
+func (s *fixedPremiumFeeStrategy) Name() string {
+	return FixedPremiumStrategy
+}
+
+func (s *fixedPremiumFeeStrategy) SetFee(msg *venusTypes.UnsignedMessage, feeCtx *FeeContext) error {
+	if feeCtx.Params == nil || feeCtx.Params.FixedPremium.NilOrZero() {
+		return xerrors.Errorf("fixed premium not set")
+	}
+	replacePremium(msg, feeCtx.Params.FixedPremium)
+	CapGasFee(msg, feeCtx.MaxFee)
+	return nil
+}
+
+// baseFeeMultiplierFeeStrategy cap the fee cap at multiple of current base fee
+type baseFeeMultiplierFeeStrategy struct{}
+
+func (s *baseFeeMultiplierFeeStrategy) Name() string {
+	return BaseFeeMultiplierStrategy
+}
+
+func (s *baseFeeMultiplierFeeStrategy) SetFee(msg *venusTypes.UnsignedMessage, feeCtx *FeeContext) error {
+	if feeCtx.BaseFee.NilOrZero() {
+		return xerrors.Errorf("base fee not found")
+	}
+	multiplier := defBaseFeeMultiplier
+	if feeCtx.Params != nil && feeCtx.Params.BaseFeeMultiplier > 0 {
+		multiplier = feeCtx.Params.BaseFeeMultiplier
+	}
+	feeCap := mulFloat(feeCtx.BaseFee, multiplier)
+	if msg.GasFeeCap.GreaterThan(feeCap) {
+		msg.GasFeeCap = feeCap
+		msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium)
+	}
+	CapGasFee(msg, feeCtx.MaxFee)
+	return nil
+}
+
+// premiumPercentileFeeStrategy pay the percentile of premiums paid by messages in recent tipsets
+type premiumPercentileFeeStrategy struct{}
+
+func (s *premiumPercentileFeeStrategy) Name() string {
+	return PremiumPercentileStrategy
+}
+
+func (s *premiumPercentileFeeStrategy) SetFee(msg *venusTypes.UnsignedMessage, feeCtx *FeeContext) error {
+	// not enough data, keep the premium estimated by node
+	if len(feeCtx.RecentPremiums) > 0 {
+		percentile := defPremiumPercentile
+		if feeCtx.Params != nil && feeCtx.Params.PremiumPercentile > 0 && feeCtx.Params.PremiumPercentile <= 100 {
+			percentile = feeCtx.Params.PremiumPercentile
+		}
+		idx := (len(feeCtx.RecentPremiums) - 1) * percentile / 100
+		replacePremium(msg, feeCtx.RecentPremiums[idx])
+	}
+	CapGasFee(msg, feeCtx.MaxFee)
+	return nil
+}
+
+// replacePremium replace the premium of msg and keep the part of fee cap reserved for base fee
+func replacePremium(msg *venusTypes.UnsignedMessage, premium big.Int) {
+	baseFeePart := big.Sub(msg.GasFeeCap, msg.GasPremium)
+	if baseFeePart.LessThan(big.Zero()) {
+		baseFeePart = big.Zero()
+	}
+	msg.GasPremium = premium
+	msg.GasFeeCap = big.Add(baseFeePart, premium)
+}
+
+func mulFloat(val big.Int, f float64) big.Int {
+	// keep three decimal places
+	return big.Div(big.Mul(val, big.NewInt(int64(f*1000))), big.NewInt(1000))
+}
+
+func CapGasFee(msg *venusTypes.UnsignedMessage, maxFee abi.TokenAmount) {
+	if maxFee.NilOrZero() {
+		return
+	}
+
+	gl := venusTypes.NewInt(uint64(msg.GasLimit))
+	totalFee := venusTypes.BigMul(msg.GasFeeCap, gl)
+
+	if totalFee.LessThanEqual(maxFee) {
+		return
+	}
+
+	msg.GasFeeCap = big.Div(maxFee, gl)
+	msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium) // cap premium at FeeCap
+}
+
+// CapGasFeeCap cap the fee cap of msg at maxFeeCap
+func CapGasFeeCap(msg *venusTypes.UnsignedMessage, maxFeeCap abi.TokenAmount) {
+	if maxFeeCap.NilOrZero() {
+		return
+	}
+
+	if msg.GasFeeCap.LessThanEqual(maxFeeCap) {
+		return
+	}
+
+	msg.GasFeeCap = maxFeeCap
+	msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium)
+}
+
+// premiumHistory keep the premiums of messages in recent tipsets
+type premiumHistory struct {
+	lk       sync.Mutex
+	heights  []abi.ChainEpoch
+	premiums map[abi.ChainEpoch][]big.Int
+}
+
+func newPremiumHistory() *premiumHistory {
+	return &premiumHistory{premiums: make(map[abi.ChainEpoch][]big.Int)}
+}
+
+func (ph *premiumHistory) add(height abi.ChainEpoch, premiums []big.Int) {
+	ph.lk.Lock()
+	defer ph.lk.Unlock()
+
+	if _, ok := ph.premiums[height]; !ok {
+		ph.heights = append(ph.heights, height)
+	}
+	ph.premiums[height] = premiums
+	if len(ph.heights) > premiumHistoryLen {
+		sort.Slice(ph.heights, func(i, j int) bool {
+			return ph.heights[i] < ph.heights[j]
+		})
+		for _, h := range ph.heights[:len(ph.heights)-premiumHistoryLen] {
+			delete(ph.premiums, h)
+		}
+		ph.heights = ph.heights[len(ph.heights)-premiumHistoryLen:]
+	}
+}
+
+// sorted return all premiums sorted ascending
+func (ph *premiumHistory) sorted() []big.Int {
+	ph.lk.Lock()
+	defer ph.lk.Unlock()
+
+	var all []big.Int
+	for _, premiums := range ph.premiums {
+		all = append(all, premiums...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].LessThan(all[j])
+	})
+	return all
+}
+
+// feeStrategyParams resolve the strategy of address, fallback to the global one
+func feeStrategyParams(addr *types.Address, global *types.SharedParams) *types.FeeStrategyParams {
+	params := &types.FeeStrategyParams{}
+	if global != nil {
+		params.Strategy = global.FeeStrategy
+		params.FixedPremium = global.FixedPremium
+		params.BaseFeeMultiplier = global.BaseFeeMultiplier
+		params.PremiumPercentile = global.PremiumPercentile
+	}
+	if len(addr.FeeStrategy) == 0 {
+		return params
+	}
+	params.Strategy = addr.FeeStrategy
+	if !addr.FixedPremium.NilOrZero() {
+		params.FixedPremium = addr.FixedPremium
+	}
+	if addr.BaseFeeMultiplier > 0 {
+		params.BaseFeeMultiplier = addr.BaseFeeMultiplier
+	}
+	if addr.PremiumPercentile > 0 {
+		params.PremiumPercentile = addr.PremiumPercentile
+	}
+	return params
+}
+
+// messageFeeStrategy return the strategy configured for the method of message, otherwise the strategy of address
+func messageFeeStrategy(strategy string, method abi.MethodNum, global *types.SharedParams) (FeeStrategy, error) {
+	if global != nil {
+		if name, ok := global.MethodFeeStrategy[method]; ok {
+			strategy = name
+		}
+	}
+	return GetFeeStrategy(strategy)
+}
+
+// checkSharedFeeStrategy validate the global fee strategy and the strategies of methods
+func checkSharedFeeStrategy(params *types.SharedParams) error {
+	if len(params.FeeStrategy) != 0 {
+		if _, err := GetFeeStrategy(params.FeeStrategy); err != nil {
+			return err
+		}
+	}
+	for method, name := range params.MethodFeeStrategy {
+		if len(name) == 0 {
+			return xerrors.Errorf("empty fee strategy of method %d", method)
+		}
+		if _, err := GetFeeStrategy(name); err != nil {
+			return xerrors.Errorf("method %d: %v", method, err)
+		}
+	}
+	if params.PremiumPercentile < 0 || params.PremiumPercentile > 100 {
+		return xerrors.Errorf("premium percentile must between 0 and 100")
+	}
+	return nil
+}
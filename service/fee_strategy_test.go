@@ -0,0 +1,148 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestFeeStrategy(t *testing.T) {
+	newMsg := func() *venusTypes.UnsignedMessage {
+		return &venusTypes.UnsignedMessage{
+			GasLimit:   100,
+			GasFeeCap:  big.NewInt(1000),
+			GasPremium: big.NewInt(200),
+		}
+	}
+
+	t.Run("node-estimate", func(t *testing.T) {
+		strategy, err := GetFeeStrategy("")
+		assert.NoError(t, err)
+		assert.Equal(t, NodeEstimateStrategy, strategy.Name())
+
+		msg := newMsg()
+		assert.NoError(t, strategy.SetFee(msg, &FeeContext{MaxFee: big.NewInt(50000)}))
+		assert.Equal(t, big.NewInt(500), msg.GasFeeCap)
+		assert.Equal(t, big.NewInt(200), msg.GasPremium)
+	})
+
+	t.Run("fixed-premium", func(t *testing.T) {
+		strategy, err := GetFeeStrategy(FixedPremiumStrategy)
+		assert.NoError(t, err)
+
+		msg := newMsg()
+		assert.Error(t, strategy.SetFee(msg, &FeeContext{Params: &types.FeeStrategyParams{}}))
+
+		assert.NoError(t, strategy.SetFee(msg, &FeeContext{Params: &types.FeeStrategyParams{FixedPremium: big.NewInt(50)}}))
+		assert.Equal(t, big.NewInt(50), msg.GasPremium)
+		assert.Equal(t, big.NewInt(850), msg.GasFeeCap)
+	})
+
+	t.Run("basefee-multiplier", func(t *testing.T) {
+		strategy, err := GetFeeStrategy(BaseFeeMultiplierStrategy)
+		assert.NoError(t, err)
+
+		msg := newMsg()
+		assert.Error(t, strategy.SetFee(msg, &FeeContext{}))
+
+		assert.NoError(t, strategy.SetFee(msg, &FeeContext{
+			BaseFee: big.NewInt(100),
+			Params:  &types.FeeStrategyParams{BaseFeeMultiplier: 1.5},
+		}))
+		assert.Equal(t, big.NewInt(150), msg.GasFeeCap)
+		assert.Equal(t, big.NewInt(150), msg.GasPremium)
+	})
+
+	t.Run("premium-percentile", func(t *testing.T) {
+		strategy, err := GetFeeStrategy(PremiumPercentileStrategy)
+		assert.NoError(t, err)
+
+		msg := newMsg()
+		assert.NoError(t, strategy.SetFee(msg, &FeeContext{}))
+		assert.Equal(t, big.NewInt(200), msg.GasPremium)
+
+		ph := newPremiumHistory()
+		for i := 0; i < premiumHistoryLen+5; i++ {
+			ph.add(abi.ChainEpoch(10+i), []big.Int{big.NewInt(int64(i * 10))})
+		}
+		premiums := ph.sorted()
+		assert.Len(t, premiums, premiumHistoryLen)
+		assert.Equal(t, big.NewInt(50), premiums[0])
+
+		assert.NoError(t, strategy.SetFee(msg, &FeeContext{
+			RecentPremiums: premiums,
+			Params:         &types.FeeStrategyParams{PremiumPercentile: 100},
+		}))
+		assert.Equal(t, big.NewInt(140), msg.GasPremium)
+		assert.Equal(t, big.NewInt(940), msg.GasFeeCap)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := GetFeeStrategy("unknown")
+		assert.Error(t, err)
+	})
+}
+
+func TestFeeStrategyParams(t *testing.T) {
+	global := &types.SharedParams{
+		FeeStrategy:       FixedPremiumStrategy,
+		FixedPremium:      big.NewInt(100),
+		BaseFeeMultiplier: 2,
+	}
+
+	params := feeStrategyParams(&types.Address{}, global)
+	assert.Equal(t, FixedPremiumStrategy, params.Strategy)
+	assert.Equal(t, big.NewInt(100), params.FixedPremium)
+
+	params = feeStrategyParams(&types.Address{FeeStrategy: BaseFeeMultiplierStrategy, BaseFeeMultiplier: 3}, global)
+	assert.Equal(t, BaseFeeMultiplierStrategy, params.Strategy)
+	assert.Equal(t, float64(3), params.BaseFeeMultiplier)
+	assert.Equal(t, big.NewInt(100), params.FixedPremium)
+}
+
+func TestMethodFeeStrategy(t *testing.T) {
+	global := &types.SharedParams{
+		FeeStrategy:       FixedPremiumStrategy,
+		MethodFeeStrategy: map[abi.MethodNum]string{5: NodeEstimateStrategy},
+	}
+	assert.NoError(t, checkSharedFeeStrategy(global))
+
+	strategy, err := messageFeeStrategy(global.FeeStrategy, 5, global)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeEstimateStrategy, strategy.Name())
+	strategy, err = messageFeeStrategy(global.FeeStrategy, 6, global)
+	assert.NoError(t, err)
+	assert.Equal(t, FixedPremiumStrategy, strategy.Name())
+
+	encoded := types.EncodeMethodFeeStrategy(map[abi.MethodNum]string{5: NodeEstimateStrategy, 26: FixedPremiumStrategy})
+	assert.Equal(t, "26:fixed-premium,5:node-estimate", encoded)
+	assert.Equal(t, map[abi.MethodNum]string{5: NodeEstimateStrategy, 26: FixedPremiumStrategy}, types.DecodeMethodFeeStrategy(encoded))
+
+	assert.Error(t, checkSharedFeeStrategy(&types.SharedParams{FeeStrategy: "unknown"}))
+	assert.Error(t, checkSharedFeeStrategy(&types.SharedParams{MethodFeeStrategy: map[abi.MethodNum]string{5: "unknown"}}))
+	assert.Error(t, checkSharedFeeStrategy(&types.SharedParams{PremiumPercentile: 101}))
+}
+
+func TestCapGasFeeCap(t *testing.T) {
+	msg := &venusTypes.UnsignedMessage{
+		GasLimit:   100,
+		GasFeeCap:  big.NewInt(1000),
+		GasPremium: big.NewInt(200),
+	}
+
+	CapGasFeeCap(msg, big.Zero())
+	assert.Equal(t, big.NewInt(1000), msg.GasFeeCap)
+
+	CapGasFeeCap(msg, big.NewInt(500))
+	assert.Equal(t, big.NewInt(500), msg.GasFeeCap)
+	assert.Equal(t, big.NewInt(200), msg.GasPremium)
+
+	CapGasFeeCap(msg, big.NewInt(100))
+	assert.Equal(t, big.NewInt(100), msg.GasFeeCap)
+	assert.Equal(t, big.NewInt(100), msg.GasPremium)
+}
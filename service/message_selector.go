@@ -2,12 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/filecoin-project/go-address"
-	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus-wallet/core"
 	"github.com/filecoin-project/venus/pkg/crypto"
@@ -22,8 +22,13 @@ import (
 	"github.com/filecoin-project/venus-messager/types"
 )
 
+// max addresses selecting messages at the same time
+const selectConcurrency = 10
+
 const (
 	gasEstimate = "gas estimate: "
+	feeStrategy = "fee strategy: "
+	holdMsg     = "hold msg: "
 	signMsg     = "sign msg: "
 )
 
@@ -35,6 +40,13 @@ type MessageSelector struct {
 	addressService *AddressService
 	sps            *SharedParamsService
 	walletClient   gateway.IWalletClient
+	policyService  *SignPolicyService
+	nodeService    *NodeService
+	failover       *NodeFailover
+
+	premiums    *premiumHistory
+	baseFeeGate *baseFeeGate
+	fairShare   *fairShareCredits
 }
 
 type MsgSelectResult struct {
@@ -43,6 +55,9 @@ type MsgSelectResult struct {
 	ToPushMsg     []*venusTypes.SignedMessage
 	ModifyAddress []*types.Address
 	ErrMsg        []msgErrInfo
+	BlockedMsg    []msgErrInfo
+	// nonce of addresses when selecting started, the messages of address whose nonce changed before saved are dropped
+	StartNonce map[address.Address]uint64
 }
 
 type msgErrInfo struct {
@@ -56,7 +71,10 @@ func NewMessageSelector(repo repo.Repo,
 	nodeClient *NodeClient,
 	addressService *AddressService,
 	sps *SharedParamsService,
-	walletClient *gateway.IWalletCli) *MessageSelector {
+	walletClient *gateway.IWalletCli,
+	policyService *SignPolicyService,
+	nodeService *NodeService,
+	failover *NodeFailover) *MessageSelector {
 	return &MessageSelector{repo: repo,
 		log:            logger,
 		cfg:            cfg,
@@ -64,6 +82,12 @@ func NewMessageSelector(repo repo.Repo,
 		addressService: addressService,
 		sps:            sps,
 		walletClient:   walletClient,
+		policyService:  policyService,
+		nodeService:    nodeService,
+		failover:       failover,
+		premiums:       newPremiumHistory(),
+		baseFeeGate:    newBaseFeeGate(),
+		fairShare:      newFairShareCredits(),
 	}
 }
 
@@ -75,7 +99,17 @@ func (messageSelector *MessageSelector) SelectMessage(ctx context.Context, ts *v
 	addrList := messageSelector.uniqAddresses(allAddrs)
 	addrSelMsgNum := messageSelector.addrSelectMsgNum(allAddrs)
 
-	appliedNonce, err := messageSelector.getNonceInTipset(ctx, ts)
+	appliedNonce, premiums, err := messageSelector.getNonceInTipset(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	addrBudget, addrNonces, err := messageSelector.signBudget(ctx, appliedNonce, ts, addrList, addrSelMsgNum)
+	if err != nil {
+		return nil, err
+	}
+	messageSelector.premiums.add(ts.Height(), premiums)
+	recentPremiums := messageSelector.premiums.sorted()
+	checker, err := messageSelector.policyService.newChecker(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -85,13 +119,20 @@ func (messageSelector *MessageSelector) SelectMessage(ctx context.Context, ts *v
 	})
 
 	messageSelector.log.Infof("%d address wait to process", len(addrList))
-	selectResult := &MsgSelectResult{}
+	selectResult := &MsgSelectResult{StartNonce: make(map[address.Address]uint64, len(addrList))}
+	for _, addr := range addrList {
+		selectResult.StartNonce[addr.Addr] = addr.Nonce
+	}
+	messageSelector.baseFeeGate.startRound()
 	var lk sync.Mutex
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, 10)
+	sem := make(chan struct{}, selectConcurrency)
 	wg.Add(len(addrList))
 	for _, addr := range addrList {
+		startNonce := addr.Nonce
 		selMsgNum := addrSelMsgNum[addr.Addr]
+		budget := addrBudget[addr.Addr]
+		nonce := addrNonces[addr.Addr]
 		go func(addr *types.Address) {
 			sem <- struct{}{}
 			defer func() {
@@ -99,7 +140,7 @@ func (messageSelector *MessageSelector) SelectMessage(ctx context.Context, ts *v
 				<-sem
 			}()
 
-			addrSelResult, err := messageSelector.selectAddrMessage(ctx, appliedNonce, addr, ts, selMsgNum)
+			addrSelResult, err := messageSelector.selectAddrMessage(ctx, appliedNonce, addr, ts, nonce, selMsgNum, budget, recentPremiums, checker)
 			if err != nil {
 				messageSelector.log.Errorf("select message of %s fail %v", addr.Addr, err)
 				return
@@ -109,20 +150,23 @@ func (messageSelector *MessageSelector) SelectMessage(ctx context.Context, ts *v
 
 			selectResult.ExpireMsg = append(selectResult.ExpireMsg, addrSelResult.ExpireMsg...)
 			selectResult.ToPushMsg = append(selectResult.ToPushMsg, addrSelResult.ToPushMsg...)
-			if len(addrSelResult.SelectMsg) > 0 {
-				selectResult.SelectMsg = append(selectResult.SelectMsg, addrSelResult.SelectMsg...)
+			selectResult.SelectMsg = append(selectResult.SelectMsg, addrSelResult.SelectMsg...)
+			if len(addrSelResult.SelectMsg) > 0 || addr.Nonce != startNonce {
 				selectResult.ModifyAddress = append(selectResult.ModifyAddress, addr)
 			}
 			selectResult.ErrMsg = append(selectResult.ErrMsg, addrSelResult.ErrMsg...)
+			selectResult.BlockedMsg = append(selectResult.BlockedMsg, addrSelResult.BlockedMsg...)
 		}(addr)
 	}
 
 	wg.Wait()
+	messageSelector.baseFeeGate.finishRound()
 
 	return selectResult, nil
 }
 
-func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, appliedNonce *types.NonceMap, addr *types.Address, ts *venusTypes.TipSet, maxAllowPendingMessage uint64) (*MsgSelectResult, error) {
+// selectAddrMessage select and sign messages of addr, nonce is the nonce of addr got from chain in this round, nil if not got yet
+func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, appliedNonce *types.NonceMap, addr *types.Address, ts *venusTypes.TipSet, nonce *chainNonce, maxAllowPendingMessage, budget uint64, recentPremiums []big.Int, checker *signPolicyChecker) (*MsgSelectResult, error) {
 	if addr.State != types.Alive && addr.State != types.Forbiden {
 		messageSelector.log.Infof("address %v state is %s, skip select unchain message", addr.Addr, types.StateToString(addr.State))
 		return nil, nil
@@ -131,41 +175,36 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 	var toPushMessage []*venusTypes.SignedMessage
 
 	//判断是否需要推送消息
-	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
-	defer cancel()
-	actorI, err := handleTimeout(messageSelector.nodeClient.StateGetActor, timeoutCtx, []interface{}{addr.Addr, ts.Key()})
-	if err != nil {
-		return nil, err
-	}
-	actor := actorI.(*venusTypes.Actor)
-	nonceInLatestTs := actor.Nonce
-	//todo actor nonce maybe the latest ts. not need appliedNonce
-	if nonceInTs, ok := appliedNonce.Get(addr.Addr); ok {
-		messageSelector.log.Infof("update address %s nonce in ts %d  nonce in actor %d", addr.Addr, nonceInTs, nonceInLatestTs)
-		nonceInLatestTs = nonceInTs
+	var err error
+	if nonce == nil {
+		nonce, err = messageSelector.nonceInLatestTs(ctx, appliedNonce, addr.Addr, ts)
+		if err != nil {
+			return nil, err
+		}
 	}
+	actorNonce, nonceInLatestTs := nonce.actorNonce, nonce.nonceInLatestTs
 	if nonceInLatestTs > addr.Nonce {
 		messageSelector.log.Warnf("%s nonce in db %d is smaller than nonce on chain %d, update to latest", addr.Addr, addr.Nonce, nonceInLatestTs)
+		// saved with the selected messages
 		addr.Nonce = nonceInLatestTs
 		addr.UpdatedAt = time.Now()
-		err := messageSelector.repo.AddressRepo().UpdateNonce(ctx, addr.Addr, addr.Nonce)
-		if err != nil {
-			return nil, xerrors.Errorf("update address %s nonce failed %v", addr.Addr, err)
-		}
 	}
 
-	filledMessage, err := messageSelector.repo.MessageRepo().ListFilledMessageByAddress(addr.Addr)
-	if err != nil {
-		messageSelector.log.Warnf("list filled message %v", err)
-	}
-	for _, msg := range filledMessage {
-		if nonceInLatestTs > msg.Nonce {
-			continue
+	// filled messages missing in mpool are republished by mpool watcher if enabled
+	if messageSelector.cfg.MpoolWatchInterval <= 0 {
+		filledMessage, err := messageSelector.repo.MessageRepo().ListFilledMessageByAddress(addr.Addr)
+		if err != nil {
+			messageSelector.log.Warnf("list filled message %v", err)
+		}
+		for _, msg := range filledMessage {
+			if nonceInLatestTs > msg.Nonce {
+				continue
+			}
+			toPushMessage = append(toPushMessage, &venusTypes.SignedMessage{
+				Message:   msg.UnsignedMessage,
+				Signature: *msg.Signature,
+			})
 		}
-		toPushMessage = append(toPushMessage, &venusTypes.SignedMessage{
-			Message:   msg.UnsignedMessage,
-			Signature: *msg.Signature,
-		})
 	}
 
 	//calc the message needed
@@ -177,19 +216,53 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 		}, nil
 	}
 	wantCount := maxAllowPendingMessage - nonceGap
-	messageSelector.log.Infof("address %s pre state actor nonce %d, latest nonce %d, assigned nonce %d, nonce gap %d, want %d", addr.Addr, actor.Nonce, nonceInLatestTs, addr.Nonce, nonceGap, wantCount)
+	if wantCount > budget {
+		messageSelector.log.Infof("%s want %d message, limited to %d by max sign per epoch", addr.Addr, wantCount, budget)
+		wantCount = budget
+	}
+	if wantCount == 0 {
+		return &MsgSelectResult{
+			ToPushMsg: toPushMessage,
+		}, nil
+	}
+	messageSelector.log.Infof("address %s pre state actor nonce %d, latest nonce %d, assigned nonce %d, nonce gap %d, want %d", addr.Addr, actorNonce, nonceInLatestTs, addr.Nonce, nonceGap, wantCount)
 	//get message
 	selectCount := mathutil.MinUint64(wantCount*2, 100)
-	messages, err := messageSelector.repo.MessageRepo().ListUnChainMessageByAddress(addr.Addr, int(selectCount))
-	if err != nil {
-		return nil, xerrors.Errorf("list %s unpackage message error %v", addr.Addr, err)
+	baseFee := ts.Blocks()[0].ParentBaseFee
+	threshold := messageSelector.baseFeeGate.threshold(addr, messageSelector.sps.GetParams().SharedParams)
+	var messages []*types.Message
+	// messages of fair share are sorted in each account, sort all again would break the interleave
+	fairShare := false
+	if messageSelector.baseFeeGate.gated(baseFee, threshold) {
+		// only urgent and high priority messages go through while base fee is high
+		messages, err = messageSelector.repo.MessageRepo().ListUnChainMessageByAddressAndPriority(addr.Addr, types.PriorityHigh, int(selectCount))
+		if err != nil {
+			return nil, xerrors.Errorf("list %s unpackage message error %v", addr.Addr, err)
+		}
+		heldCount, err := messageSelector.repo.MessageRepo().CountUnChainMessageBelowPriority(addr.Addr, types.PriorityHigh)
+		if err != nil {
+			return nil, xerrors.Errorf("count %s held message error %v", addr.Addr, err)
+		}
+		messageSelector.baseFeeGate.setAddress(addr.Addr, threshold, true, heldCount)
+		messageSelector.log.Infof("base fee %s above threshold %s, %s hold %d message", baseFee, threshold, addr.Addr, heldCount)
+	} else {
+		if messageSelector.sps.GetParams().FairShare {
+			fairShare = true
+			messages, err = messageSelector.listFairShareMessage(ctx, addr.Addr, int(selectCount))
+		} else {
+			messages, err = messageSelector.repo.MessageRepo().ListUnChainMessageByAddress(addr.Addr, int(selectCount))
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("list %s unpackage message error %v", addr.Addr, err)
+		}
+		messageSelector.baseFeeGate.setAddress(addr.Addr, threshold, false, 0)
 	}
 
 	//exclude expire message
 	messages, expireMsgs := messageSelector.excludeExpire(ts, messages)
-	sort.Slice(messages, func(i, j int) bool {
-		return messages[i].Meta.ExpireEpoch < messages[j].Meta.ExpireEpoch
-	})
+	if !fairShare {
+		sortByExpireEpoch(messages)
+	}
 
 	//todo 如何筛选
 	if len(messages) == 0 {
@@ -203,11 +276,41 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 	var count = uint64(0)
 	var selectMsg []*types.Message
 	var errMsg []msgErrInfo
+	var blockedMsg []msgErrInfo
 
-	estimateMesssages := make([]*EstimateMessage, len(messages))
-	for index, msg := range messages {
+	sharedParams := messageSelector.sps.GetParams().SharedParams
+	strategyParams := feeStrategyParams(addr, sharedParams)
+
+	// hold messages whose max fee cap can not afford current base fee
+	var holdCount int
+	candidates := make([]*types.Message, 0, len(messages))
+	msgMetas := make([]*types.MsgMeta, 0, len(messages))
+	for _, msg := range messages {
 		// global msg meta
 		newMsgMeta := messageSelector.messageMeta(msg.Meta, addr)
+		if !newMsgMeta.MaxFeeCap.NilOrZero() && baseFee.GreaterThan(newMsgMeta.MaxFeeCap) {
+			reason := fmt.Sprintf("base fee %s exceeds max fee cap %s", baseFee, newMsgMeta.MaxFeeCap)
+			errMsg = append(errMsg, msgErrInfo{id: msg.ID, err: holdMsg + reason})
+			messageSelector.log.Infof("hold message %s, %s", msg.ID, reason)
+			holdCount++
+			continue
+		}
+		candidates = append(candidates, msg)
+		msgMetas = append(msgMetas, newMsgMeta)
+	}
+	messages = candidates
+	if len(messages) == 0 {
+		messageSelector.log.Infof("%s all of %d message are held", addr.Addr, holdCount)
+		return &MsgSelectResult{
+			ExpireMsg: expireMsgs,
+			ToPushMsg: toPushMessage,
+			ErrMsg:    errMsg,
+		}, nil
+	}
+
+	estimateMesssages := make([]*EstimateMessage, len(messages))
+	for index, msg := range messages {
+		newMsgMeta := msgMetas[index]
 		estimateMesssages[index] = &EstimateMessage{
 			Msg: &msg.UnsignedMessage,
 			Spec: &venusTypes.MessageSendSpec{
@@ -218,9 +321,7 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 		messageSelector.log.Infof("estimate message %s meta maxfee %s, max fee cap %s, over estimation %f", msg.ID, newMsgMeta.MaxFee, newMsgMeta.MaxFeeCap, newMsgMeta.GasOverEstimation)
 	}
 
-	timeOutCtx, cancel := context.WithTimeout(ctx, time.Second*5)
-	estimateResult, err := messageSelector.nodeClient.GasBatchEstimateMessageGas(timeOutCtx, estimateMesssages, addr.Nonce, ts.Key())
-	cancel()
+	estimateResult, err := messageSelector.batchEstimateMessageGas(ctx, estimateMesssages, addr.Nonce, ts.Key())
 	if err != nil {
 		return nil, err
 	}
@@ -238,35 +339,70 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 			break
 		}
 
+		strategy, err := messageFeeStrategy(strategyParams.Strategy, msg.Method, sharedParams)
+		if err != nil {
+			errMsg = append(errMsg, msgErrInfo{id: msg.ID, err: feeStrategy + err.Error()})
+			messageSelector.log.Errorf("get fee strategy of message %s fail %v", msg.ID, err)
+			continue
+		}
+		feeCtx := &FeeContext{
+			BaseFee:        baseFee,
+			MaxFee:         msgMetas[index].MaxFee,
+			RecentPremiums: recentPremiums,
+			Params:         strategyParams,
+		}
+		if err := strategy.SetFee(estimateMsg, feeCtx); err != nil {
+			errMsg = append(errMsg, msgErrInfo{id: msg.ID, err: feeStrategy + err.Error()})
+			messageSelector.log.Errorf("%s set fee of message %s fail %v", strategy.Name(), msg.ID, err)
+			continue
+		}
+		CapGasFeeCap(estimateMsg, msgMetas[index].MaxFeeCap)
+
 		//分配nonce
 		msg.Nonce = addr.Nonce
 		msg.GasFeeCap = estimateMsg.GasFeeCap
 		msg.GasPremium = estimateMsg.GasPremium
 		msg.GasLimit = estimateMsg.GasLimit
 
+		reason, err := checker.check(msg)
+		if err != nil {
+			errMsg = append(errMsg, msgErrInfo{id: msg.ID, err: signPolicyPrefix + err.Error()})
+			messageSelector.log.Errorf("check sign policy of message %s fail %v", msg.ID, err)
+			continue
+		}
+		if len(reason) > 0 {
+			blockedMsg = append(blockedMsg, msgErrInfo{id: msg.ID, err: reason})
+			messageSelector.log.Warnf("message %s blocked, %s", msg.ID, reason)
+			continue
+		}
+
 		unsignedCid := msg.UnsignedMessage.Cid()
 		msg.UnsignedCid = &unsignedCid
 		//签名
 		data, err := msg.UnsignedMessage.ToStorageBlock()
 		if err != nil {
+			checker.release(msg)
 			messageSelector.log.Errorf("calc message unsigned message id %s fail %v", msg.ID, err)
 			continue
 		}
 
-		timeOutCtx, cancel = context.WithTimeout(ctx, time.Second)
+		timeOutCtx, cancel := context.WithTimeout(ctx, time.Second)
 		sigI, err := handleTimeout(messageSelector.walletClient.WalletSign, timeOutCtx, []interface{}{msg.WalletName, addr.Addr, unsignedCid.Bytes(), core.MsgMeta{
 			Type:  core.MTChainMsg,
 			Extra: data.RawData(),
 		}})
 		cancel()
 		if err != nil {
+			checker.release(msg)
 			errMsg = append(errMsg, msgErrInfo{id: msg.ID, err: signMsg + err.Error()})
 			messageSelector.log.Errorf("wallet sign failed %s fail %v", msg.ID, err)
 			break
 		}
 
 		sig := sigI.(*crypto.Signature)
+		signedAt := time.Now()
 		msg.Signature = sig
+		msg.SignedAt = &signedAt
 		msg.State = types.FillMsg
 
 		//signed cid for t1 address
@@ -282,16 +418,42 @@ func (messageSelector *MessageSelector) selectAddrMessage(ctx context.Context, a
 		count++
 	}
 
-	messageSelector.log.Infof("address %s select message %d ExpireMsgs %d ToPushMsgs %d ErrMsgs %d max nonce %d",
-		addr.Addr, len(selectMsg), len(expireMsgs), len(toPushMessage), len(errMsg), addr.Nonce)
+	messageSelector.log.Infof("address %s select message %d ExpireMsgs %d ToPushMsgs %d ErrMsgs %d HoldMsgs %d BlockedMsgs %d max nonce %d",
+		addr.Addr, len(selectMsg), len(expireMsgs), len(toPushMessage), len(errMsg), holdCount, len(blockedMsg), addr.Nonce)
 	return &MsgSelectResult{
-		SelectMsg: selectMsg,
-		ExpireMsg: expireMsgs,
-		ToPushMsg: toPushMessage,
-		ErrMsg:    errMsg,
+		SelectMsg:  selectMsg,
+		ExpireMsg:  expireMsgs,
+		ToPushMsg:  toPushMessage,
+		ErrMsg:     errMsg,
+		BlockedMsg: blockedMsg,
 	}, nil
 }
 
+// nonceInLatestTs return the nonce of actor in the parent state of ts, and the latest nonce with the messages in ts applied
+// chainNonce is the nonce of address on chain
+type chainNonce struct {
+	actorNonce uint64
+	// nonce after the messages applied in the latest tipset
+	nonceInLatestTs uint64
+}
+
+func (messageSelector *MessageSelector) nonceInLatestTs(ctx context.Context, appliedNonce *types.NonceMap, addr address.Address, ts *venusTypes.TipSet) (*chainNonce, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	actorI, err := handleTimeout(messageSelector.nodeClient.StateGetActor, timeoutCtx, []interface{}{addr, ts.Key()})
+	if err != nil {
+		return nil, err
+	}
+	actor := actorI.(*venusTypes.Actor)
+	nonceInLatestTs := actor.Nonce
+	//todo actor nonce maybe the latest ts. not need appliedNonce
+	if nonceInTs, ok := appliedNonce.Get(addr); ok {
+		messageSelector.log.Infof("update address %s nonce in ts %d  nonce in actor %d", addr, nonceInTs, nonceInLatestTs)
+		nonceInLatestTs = nonceInTs
+	}
+	return &chainNonce{actorNonce: actor.Nonce, nonceInLatestTs: nonceInLatestTs}, nil
+}
+
 func (messageSelector *MessageSelector) excludeExpire(ts *venusTypes.TipSet, msgs []*types.Message) ([]*types.Message, []*types.Message) {
 	//todo check whether message is expired
 	var result []*types.Message
@@ -308,6 +470,13 @@ func (messageSelector *MessageSelector) excludeExpire(ts *venusTypes.TipSet, msg
 	return result, expireMsg
 }
 
+// sortByExpireEpoch sort messages by expire epoch, and keep the order of messages with the same expire epoch
+func sortByExpireEpoch(msgs []*types.Message) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].Meta.ExpireEpoch < msgs[j].Meta.ExpireEpoch
+	})
+}
+
 func (messageSelector *MessageSelector) messageMeta(meta *types.MsgMeta, addrInfo *types.Address) *types.MsgMeta {
 	newMsgMeta := &types.MsgMeta{}
 	*newMsgMeta = *meta
@@ -338,10 +507,12 @@ func (messageSelector *MessageSelector) messageMeta(meta *types.MsgMeta, addrInf
 	return newMsgMeta
 }
 
-func (messageSelector *MessageSelector) getNonceInTipset(ctx context.Context, ts *venusTypes.TipSet) (*types.NonceMap, error) {
+func (messageSelector *MessageSelector) getNonceInTipset(ctx context.Context, ts *venusTypes.TipSet) (*types.NonceMap, []big.Int, error) {
 	applied := types.NewNonceMap()
+	var premiums []big.Int
 	//todo change with venus/lotus message for tipset
 	selectMsg := func(m *venusTypes.Message) error {
+		premiums = append(premiums, m.GasPremium)
 		// The first match for a sender is guaranteed to have correct nonce -- the block isn't valid otherwise
 		if _, ok := applied.Get(m.From); !ok {
 			applied.Add(m.From, m.Nonce)
@@ -359,13 +530,13 @@ func (messageSelector *MessageSelector) getNonceInTipset(ctx context.Context, ts
 	for _, b := range ts.Blocks() {
 		fullBlk, err := messageSelector.nodeClient.ChainGetBlockMessages(ctx, b.Cid())
 		if err != nil {
-			return nil, xerrors.Errorf("failed to get messages for block: %w", err)
+			return nil, nil, xerrors.Errorf("failed to get messages for block: %w", err)
 		}
 
 		for _, bmsg := range fullBlk.BlsMessages {
 			err := selectMsg(bmsg.VMMessage())
 			if err != nil {
-				return nil, xerrors.Errorf("failed to decide whether to select message for block: %w", err)
+				return nil, nil, xerrors.Errorf("failed to decide whether to select message for block: %w", err)
 			}
 
 		}
@@ -373,12 +544,12 @@ func (messageSelector *MessageSelector) getNonceInTipset(ctx context.Context, ts
 		for _, smsg := range fullBlk.SecpkMessages {
 			err := selectMsg(smsg.VMMessage())
 			if err != nil {
-				return nil, xerrors.Errorf("failed to decide whether to select message for block: %w", err)
+				return nil, nil, xerrors.Errorf("failed to decide whether to select message for block: %w", err)
 			}
 		}
 	}
 
-	return applied, nil
+	return applied, premiums, nil
 }
 func (messageSelector *MessageSelector) GasEstimateMessageGas(ctx context.Context, msg *venusTypes.UnsignedMessage, meta *types.MsgMeta, tsk venusTypes.TipSetKey) (*venusTypes.UnsignedMessage, error) {
 	if msg.GasLimit == 0 {
@@ -408,6 +579,7 @@ func (messageSelector *MessageSelector) GasEstimateMessageGas(ctx context.Contex
 	}
 
 	CapGasFee(msg, meta.MaxFee)
+	CapGasFeeCap(msg, meta.MaxFeeCap)
 
 	return msg, nil
 }
@@ -445,19 +617,3 @@ func (messageSelector *MessageSelector) addrSelectMsgNum(addrList []*types.Addre
 
 	return selMsgNum
 }
-
-func CapGasFee(msg *venusTypes.UnsignedMessage, maxFee abi.TokenAmount) {
-	if maxFee.NilOrZero() {
-		return
-	}
-
-	gl := venusTypes.NewInt(uint64(msg.GasLimit))
-	totalFee := venusTypes.BigMul(msg.GasFeeCap, gl)
-
-	if totalFee.LessThanEqual(maxFee) {
-		return
-	}
-
-	msg.GasFeeCap = big.Div(maxFee, gl)
-	msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium) // cap premium at FeeCap
-}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/filecoin-project/go-address"
-	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus-auth/cmd/jwtclient"
@@ -56,8 +55,13 @@ type MessageService struct {
 
 	messageSelector *MessageSelector
 
-	sps         *SharedParamsService
-	nodeService *NodeService
+	sps           *SharedParamsService
+	nodeService   *NodeService
+	policyService *SignPolicyService
+	limitService  *AccountLimitService
+
+	// nonceLk serialize saving the nonce of selector and imported signed messages
+	nonceLk sync.Mutex
 
 	preCancel context.CancelFunc
 }
@@ -83,8 +87,17 @@ func NewMessageService(repo repo.Repo,
 	addressService *AddressService,
 	sps *SharedParamsService,
 	nodeService *NodeService,
-	walletClient *gateway.IWalletCli) (*MessageService, error) {
-	selector := NewMessageSelector(repo, logger, cfg, nc, addressService, sps, walletClient)
+	policyService *SignPolicyService,
+	limitService *AccountLimitService,
+	walletClient *gateway.IWalletCli,
+	failover *NodeFailover) (*MessageService, error) {
+	if err := checkBackpressureConfig(cfg); err != nil {
+		return nil, err
+	}
+	if err := checkEstimateConfig(cfg); err != nil {
+		return nil, err
+	}
+	selector := NewMessageSelector(repo, logger, cfg, nc, addressService, sps, walletClient, policyService, nodeService, failover)
 	ms := &MessageService{
 		repo:            repo,
 		log:             logger,
@@ -100,9 +113,11 @@ func NewMessageService(repo repo.Repo,
 			Cache:      make(map[int64]*tipsetFormat, maxStoreTipsetCount),
 			CurrHeight: 0,
 		},
-		triggerPush: make(chan *venusTypes.TipSet, 20),
-		sps:         sps,
-		nodeService: nodeService,
+		triggerPush:   make(chan *venusTypes.TipSet, 20),
+		sps:           sps,
+		nodeService:   nodeService,
+		policyService: policyService,
+		limitService:  limitService,
 	}
 	ms.refreshMessageState(context.TODO())
 
@@ -131,13 +146,19 @@ func (ms *MessageService) pushMessage(ctx context.Context, msg *types.Message) e
 	if !has {
 		return xerrors.Errorf("wallet(%s) address %s not exists", msg.WalletName, msg.From)
 	}
+	if ms.cfg.RequireAddressBinding {
+		if err := ms.addressService.checkAddressBinding(ctx, msg.FromUser, msg.From, msg.Method); err != nil {
+			return err
+		}
+	}
 	var addrInfo *types.Address
 	if err := ms.repo.Transaction(func(txRepo repo.TxRepo) error {
 		addrInfo, err = ms.addressService.GetAddress(ctx, msg.From)
 		if err == nil {
 			return nil
 		}
-		if xerrors.Is(err, gorm.ErrRecordNotFound) {
+		// addresses are added by binding when binding required
+		if xerrors.Is(err, gorm.ErrRecordNotFound) && !ms.cfg.RequireAddressBinding {
 			if err = ms.repo.AddressRepo().SaveAddress(ctx, &types.Address{
 				ID:        types.NewUUID(),
 				Addr:      msg.From,
@@ -160,13 +181,19 @@ func (ms *MessageService) pushMessage(ctx context.Context, msg *types.Message) e
 		return xerrors.Errorf("address(%s) is forbidden", msg.From.String())
 	}
 
+	if err := ms.checkBacklog(msg); err != nil {
+		return err
+	}
+
 	msg.Nonce = 0
-	err = ms.repo.MessageRepo().CreateMessage(msg)
-	if err == nil {
-		ms.messageState.SetMessage(msg.ID, msg)
+	if err := ms.limitService.admitPush(ctx, msg, func() error {
+		return ms.repo.MessageRepo().CreateMessage(msg)
+	}); err != nil {
+		return err
 	}
+	ms.messageState.SetMessage(msg.ID, msg)
 
-	return err
+	return nil
 }
 
 func ipAccountFromContext(ctx context.Context) (string, string) {
@@ -248,6 +275,12 @@ func (ms *MessageService) WaitMessage(ctx context.Context, id string, confidence
 				return msg, nil
 			case types.NoWalletMsg:
 				return nil, xerrors.New("msg failed due to wallet disappear")
+			case types.BlockedByPolicyMsg:
+				var reason string
+				if msg.Receipt != nil {
+					reason = string(msg.Receipt.ReturnValue)
+				}
+				return nil, xerrors.Errorf("msg blocked by sign policy: %s", reason)
 			}
 
 		case <-tm.C:
@@ -267,6 +300,9 @@ func (ms *MessageService) GetMessageByUid(ctx context.Context, id string) (*type
 	if err != nil {
 		return nil, err
 	}
+	if err := checkMessageScope(ctx, msg); err != nil {
+		return nil, err
+	}
 	if msg.State == types.OnChainMsg {
 		msg.Confidence = int64(ts.Height()) - msg.Height
 	}
@@ -274,6 +310,16 @@ func (ms *MessageService) GetMessageByUid(ctx context.Context, id string) (*type
 }
 
 func (ms *MessageService) HasMessageByUid(ctx context.Context, id string) (bool, error) {
+	if _, ok := scopedAccountFromContext(ctx); ok {
+		msg, err := ms.repo.MessageRepo().GetMessageByUid(id)
+		if err != nil {
+			if xerrors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return checkMessageScope(ctx, msg) == nil, nil
+	}
 	return ms.repo.MessageRepo().HasMessageByUid(id)
 }
 
@@ -286,6 +332,9 @@ func (ms *MessageService) GetMessageByCid(ctx context.Context, id cid.Cid) (*typ
 	if err != nil {
 		return nil, err
 	}
+	if err := checkMessageScope(ctx, msg); err != nil {
+		return nil, err
+	}
 	if msg.State == types.OnChainMsg {
 		msg.Confidence = int64(ts.Height()) - msg.Height
 	}
@@ -293,23 +342,31 @@ func (ms *MessageService) GetMessageByCid(ctx context.Context, id cid.Cid) (*typ
 }
 
 func (ms *MessageService) GetMessageState(ctx context.Context, id string) (types.MessageState, error) {
+	if _, ok := scopedAccountFromContext(ctx); ok {
+		msg, err := scopedMessage(ctx)(ms.repo.MessageRepo().GetMessageByUid(id))
+		if err != nil {
+			return types.UnKnown, err
+		}
+		return msg.State, nil
+	}
 	return ms.repo.MessageRepo().GetMessageState(id)
 }
 
 func (ms *MessageService) GetMessageBySignedCid(ctx context.Context, signedCid cid.Cid) (*types.Message, error) {
-	return ms.repo.MessageRepo().GetMessageBySignedCid(signedCid)
+	return scopedMessage(ctx)(ms.repo.MessageRepo().GetMessageBySignedCid(signedCid))
 }
 
 func (ms *MessageService) GetMessageByUnsignedCid(ctx context.Context, unsignedCid cid.Cid) (*types.Message, error) {
-	return ms.repo.MessageRepo().GetMessageByCid(unsignedCid)
+	return scopedMessage(ctx)(ms.repo.MessageRepo().GetMessageByCid(unsignedCid))
 }
 
 func (ms *MessageService) GetMessageByFromAndNonce(ctx context.Context, from address.Address, nonce uint64) (*types.Message, error) {
-	return ms.repo.MessageRepo().GetMessageByFromAndNonce(from, nonce)
+	return scopedMessage(ctx)(ms.repo.MessageRepo().GetMessageByFromAndNonce(from, nonce))
 }
 
 func (ms *MessageService) ListMessageByFromState(ctx context.Context, from address.Address, state types.MessageState, pageIndex, pageSize int) ([]*types.Message, error) {
-	return ms.repo.MessageRepo().ListMessageByFromState(from, state, pageIndex, pageSize)
+	account, _ := scopedAccountFromContext(ctx)
+	return ms.repo.MessageRepo().ListMessageByFromState(from, account, state, pageIndex, pageSize)
 }
 
 func (ms *MessageService) ListMessage(ctx context.Context) ([]*types.Message, error) {
@@ -317,7 +374,8 @@ func (ms *MessageService) ListMessage(ctx context.Context) ([]*types.Message, er
 	if err != nil {
 		return nil, err
 	}
-	msgs, err := ms.repo.MessageRepo().ListMessage()
+	account, _ := scopedAccountFromContext(ctx)
+	msgs, err := ms.repo.MessageRepo().ListMessage(account)
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +393,8 @@ func (ms *MessageService) ListMessageByAddress(ctx context.Context, addr address
 	if err != nil {
 		return nil, err
 	}
-	msgs, err := ms.repo.MessageRepo().ListMessageByAddress(addr)
+	account, _ := scopedAccountFromContext(ctx)
+	msgs, err := ms.repo.MessageRepo().ListMessageByAddress(addr, account)
 	if err != nil {
 		return nil, err
 	}
@@ -349,7 +408,8 @@ func (ms *MessageService) ListMessageByAddress(ctx context.Context, addr address
 }
 
 func (ms *MessageService) ListFailedMessage(ctx context.Context) ([]*types.Message, error) {
-	return ms.repo.MessageRepo().ListFailedMessage()
+	msgs, err := ms.repo.MessageRepo().ListFailedMessage()
+	return filterMessageByScope(ctx, msgs), err
 }
 
 func (ms *MessageService) ListFilledMessageByAddress(ctx context.Context, addr address.Address) ([]*types.Message, error) {
@@ -383,6 +443,7 @@ func (ms *MessageService) ListBlockedMessage(ctx context.Context, addr address.A
 			msgs = append(msgs, msgsT...)
 		}
 	}
+	msgs = filterMessageByScope(ctx, msgs)
 
 	if len(msgs) > 0 {
 		ids := make([]string, 0, len(msgs))
@@ -395,6 +456,10 @@ func (ms *MessageService) ListBlockedMessage(ctx context.Context, addr address.A
 	return msgs, err
 }
 
+func (ms *MessageService) GetBaseFeeGate(ctx context.Context) (*types.BaseFeeGate, error) {
+	return ms.messageSelector.baseFeeGate.info(ms.sps.GetParams().SharedParams), nil
+}
+
 func (ms *MessageService) UpdateMessageStateByCid(ctx context.Context, cid string, state types.MessageState) (string, error) {
 	return cid, ms.repo.MessageRepo().UpdateMessageStateByCid(cid, state)
 }
@@ -419,6 +484,7 @@ func (ms *MessageService) ProcessNewHead(ctx context.Context, apply, revert []*v
 		ms.log.Errorf("expect apply blocks, but got none")
 		return nil
 	}
+	ms.messageSelector.baseFeeGate.setHead(apply[0])
 
 	ts := ms.tsCache.ListTs()
 	sort.Sort(ts)
@@ -591,7 +657,11 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 	tSaveDb := time.Now()
 	ms.log.Infof("start to save to database")
 	//save to db
-	if err = ms.repo.Transaction(func(txRepo repo.TxRepo) error {
+	ms.nonceLk.Lock()
+	err = ms.repo.Transaction(func(txRepo repo.TxRepo) error {
+		if err := ms.dropNonceConflict(ctx, txRepo, selectResult); err != nil {
+			return err
+		}
 		//保存消息
 		err = txRepo.MessageRepo().ExpireMessage(selectResult.ExpireMsg)
 		if err != nil {
@@ -618,8 +688,19 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 			}
 		}
 
+		for _, m := range selectResult.BlockedMsg {
+			if err := txRepo.MessageRepo().UpdateMessageStateByID(m.id, types.BlockedByPolicyMsg); err != nil {
+				return err
+			}
+			if err := txRepo.MessageRepo().UpdateReturnValue(m.id, m.err); err != nil {
+				return err
+			}
+		}
+
 		return nil
-	}); err != nil {
+	})
+	ms.nonceLk.Unlock()
+	if err != nil {
 		ms.log.Errorf("save signed message failed %v", err)
 		return err
 	}
@@ -640,6 +721,7 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 			message.UnsignedMessage = msg.UnsignedMessage
 			message.State = msg.State
 			message.Signature = msg.Signature
+			message.SignedAt = msg.SignedAt
 			message.Nonce = msg.Nonce
 			if message.Receipt != nil {
 				message.Receipt.ReturnValue = nil //cover data for err before
@@ -651,6 +733,21 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 		}
 	}
 
+	for _, m := range selectResult.BlockedMsg {
+		err := ms.messageState.MutatorMessage(m.id, func(message *types.Message) error {
+			message.State = types.BlockedByPolicyMsg
+			if message.Receipt != nil {
+				message.Receipt.ReturnValue = []byte(m.err)
+			} else {
+				message.Receipt = &venusTypes.MessageReceipt{ReturnValue: []byte(m.err)}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, m := range selectResult.ErrMsg {
 		err := ms.messageState.MutatorMessage(m.id, func(message *types.Message) error {
 			if message.Receipt != nil {
@@ -670,13 +767,17 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 	go func() {
 		tPush := time.Now()
 		ms.log.Infof("start to push message %d to mpool", len(selectResult.ToPushMsg))
+		results := make([]*types.BroadcastResult, 0, len(selectResult.ToPushMsg))
 		for _, msg := range selectResult.ToPushMsg {
-			if _, pushErr := ms.nodeClient.MpoolPush(ctx, msg); err != nil {
-				if !(strings.Contains(err.Error(), errMinimumNonce.Error()) && !strings.Contains(err.Error(), errAlreadyInMpool.Error())) {
+			_, pushErr := ms.nodeClient.MpoolPush(ctx, msg)
+			if pushErr != nil {
+				if !(strings.Contains(pushErr.Error(), errMinimumNonce.Error()) && !strings.Contains(pushErr.Error(), errAlreadyInMpool.Error())) {
 					ms.log.Errorf("push message %s to node failed %v", msg.Message.Cid().String(), pushErr)
 				}
 			}
+			results = append(results, newBroadcastResult(msg, DefaultNodeName, pushErr))
 		}
+		ms.saveBroadcastResults(results)
 
 		ms.multiNodeToPush(ctx, selectResult.ToPushMsg)
 
@@ -691,9 +792,9 @@ func (ms *MessageService) pushMessageToPool(ctx context.Context, ts *venusTypes.
 }
 
 type nodeClient struct {
-	name  string
-	cli   *NodeClient
-	close jsonrpc.ClientCloser
+	name string
+	node *types.Node
+	cli  *NodeClient
 }
 
 func (ms *MessageService) multiNodeToPush(ctx context.Context, msgs []*venusTypes.SignedMessage) {
@@ -702,20 +803,18 @@ func (ms *MessageService) multiNodeToPush(ctx context.Context, msgs []*venusType
 		return
 	}
 
-	nodeList, err := ms.nodeService.ListNode(context.TODO())
-	if err != nil {
-		ms.log.Errorf("list node %v", err)
-		return
-	}
-
-	nc := make([]nodeClient, 0, len(nodeList))
-	for _, node := range nodeList {
-		cli, closer, err := NewNodeClient(context.TODO(), &config.NodeConfig{Token: node.Token, Url: node.URL})
-		if err != nil {
-			ms.log.Warnf("connect node(%s) %v", node.Name, err)
+	now := time.Now()
+	clients := ms.nodeService.pool.clients(now)
+	nc := make([]nodeClient, 0, len(clients))
+	for _, node := range clients {
+		if !node.node.Type.HasRole(types.BroadcastRole) {
+			continue
+		}
+		if !ms.nodeService.health.healthy(node.name) {
+			ms.log.Warnf("skip unhealthy node %s", node.name)
 			continue
 		}
-		nc = append(nc, nodeClient{name: node.Name, cli: cli, close: closer})
+		nc = append(nc, node)
 	}
 
 	if len(nc) == 0 {
@@ -730,24 +829,56 @@ func (ms *MessageService) multiNodeToPush(ctx context.Context, msgs []*venusType
 		}
 	}
 
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	results := make([]*types.BroadcastResult, 0, len(nc)*len(msgs))
 	for _, node := range nc {
-		for _, msg := range msgs {
-			if _, err := node.cli.MpoolPush(ctx, msg); err != nil {
-				//skip error
-				if !(strings.Contains(err.Error(), errMinimumNonce.Error()) && !strings.Contains(err.Error(), errAlreadyInMpool.Error())) {
-					ms.log.Errorf("push message %s to node %s %v", msg.Cid(), node.name, err)
+		wg.Add(1)
+		go func(node nodeClient) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, nodePushTimeout)
+			defer cancel()
+
+			// messages left are not pushed once the node is unavailable
+			var unavailable error
+			nodeResults := make([]*types.BroadcastResult, 0, len(msgs))
+			for _, msg := range msgs {
+				err := unavailable
+				if err == nil {
+					_, err = node.cli.MpoolPush(ctx, msg)
+					if err != nil && nodeUnavailable(err) {
+						ms.log.Errorf("push message %s to node %s failed, node unavailable %v", msg.Cid(), node.name, err)
+						ms.nodeService.pool.reset(node.name, err, time.Now())
+						unavailable = err
+					} else if err != nil {
+						//skip error
+						if !(strings.Contains(err.Error(), errMinimumNonce.Error()) && !strings.Contains(err.Error(), errAlreadyInMpool.Error())) {
+							ms.log.Errorf("push message %s to node %s %v", msg.Cid(), node.name, err)
+						}
+					}
 				}
+				nodeResults = append(nodeResults, newBroadcastResult(msg, node.name, err))
 			}
-		}
-		ms.log.Infof("start to broadcast message of address")
-		for fromAddr := range fromMap {
-			if err := node.cli.MpoolPublishByAddr(ctx, fromAddr); err != nil {
-				ms.log.Errorf("publish message of address %s to node %s failed %v", fromAddr, node.name, err)
+			lk.Lock()
+			results = append(results, nodeResults...)
+			lk.Unlock()
+			if unavailable != nil {
+				return
 			}
-		}
 
-		node.close()
+			ms.log.Infof("start to broadcast message of address")
+			for fromAddr := range fromMap {
+				if err := node.cli.MpoolPublishByAddr(ctx, fromAddr); err != nil {
+					ms.log.Errorf("publish message of address %s to node %s failed %v", fromAddr, node.name, err)
+					// publish fails only when the node is unavailable, reconnect it later
+					ms.nodeService.pool.reset(node.name, err, time.Now())
+					return
+				}
+			}
+		}(node)
 	}
+	wg.Wait()
+	ms.saveBroadcastResults(results)
 }
 
 func (ms *MessageService) StartPushMessage(ctx context.Context, skipPushMsg bool) {
@@ -787,10 +918,45 @@ func (ms *MessageService) StartPushMessage(ctx context.Context, skipPushMsg bool
 	}
 }
 
+// dropNonceConflict drop the messages selected for address whose nonce changed while selecting, such as by imported
+// signed messages, they are still UnFillMsg in database and selected again in next round
+func (ms *MessageService) dropNonceConflict(ctx context.Context, txRepo repo.TxRepo, selectResult *MsgSelectResult) error {
+	conflicts := make(map[address.Address]struct{})
+	modifyAddress := make([]*types.Address, 0, len(selectResult.ModifyAddress))
+	for _, addr := range selectResult.ModifyAddress {
+		addrInfo, err := txRepo.AddressRepo().GetAddress(ctx, addr.Addr)
+		if err != nil {
+			return err
+		}
+		if addrInfo.Nonce != selectResult.StartNonce[addr.Addr] {
+			ms.log.Warnf("nonce of %s changed from %d to %d while selecting, drop the messages selected", addr.Addr,
+				selectResult.StartNonce[addr.Addr], addrInfo.Nonce)
+			conflicts[addr.Addr] = struct{}{}
+			continue
+		}
+		modifyAddress = append(modifyAddress, addr)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	// selected messages are added to ToPushMsg after saved
+	selectMsg := make([]*types.Message, 0, len(selectResult.SelectMsg))
+	for _, msg := range selectResult.SelectMsg {
+		if _, ok := conflicts[msg.From]; !ok {
+			selectMsg = append(selectMsg, msg)
+		}
+	}
+	selectResult.ModifyAddress, selectResult.SelectMsg = modifyAddress, selectMsg
+	return nil
+}
+
 func (ms *MessageService) tryResetAddress() {
 	select {
 	case f := <-ms.addressService.resetAddressFunc:
+		ms.nonceLk.Lock()
 		nonce, err := f()
+		ms.nonceLk.Unlock()
 		ms.addressService.resetAddressRes <- resetAddressResult{
 			latestNonce: nonce,
 			err:         err,
@@ -857,6 +1023,18 @@ func (ms *MessageService) ReplaceMessage(ctx context.Context, id string, auto bo
 	if msg.State == types.OnChainMsg {
 		return cid.Undef, xerrors.Errorf("message already on chain")
 	}
+	if msg.SignedExternally() {
+		// the replacement is signed by the wallet of the account imported it, if the wallet has the key
+		has, err := ms.walletClient.WalletHas(ctx, msg.FromUser, msg.From)
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("check wallet of account %s failed %v", msg.FromUser, err)
+		}
+		if !has {
+			return cid.Undef, xerrors.Errorf("message %s is signed out of messager and wallet of account %s has no key of %s, "+
+				"sign the replacement out of messager instead", id, msg.FromUser, msg.From)
+		}
+		msg.WalletName = msg.FromUser
+	}
 
 	if auto {
 		minRBF := messagepool.ComputeMinRBF(msg.GasPremium)
@@ -903,6 +1081,18 @@ func (ms *MessageService) ReplaceMessage(ctx context.Context, id string, auto bo
 		}
 	}
 
+	checker, err := ms.policyService.newChecker(ctx)
+	if err != nil {
+		return cid.Undef, err
+	}
+	reason, err := checker.check(msg)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if len(reason) > 0 {
+		return cid.Undef, xerrors.New(reason)
+	}
+
 	signedMsg, err := ToSignedMsg(ctx, ms.walletClient, msg)
 	if err != nil {
 		return cid.Undef, err
@@ -920,7 +1110,9 @@ func (ms *MessageService) ReplaceMessage(ctx context.Context, id string, auto bo
 		message.UnsignedMessage = msg.UnsignedMessage
 		message.State = msg.State
 		message.Signature = msg.Signature
+		message.SignedAt = msg.SignedAt
 		message.Nonce = msg.Nonce
+		message.WalletName = msg.WalletName
 		return nil
 	})
 	if err != nil {
@@ -928,14 +1120,42 @@ func (ms *MessageService) ReplaceMessage(ctx context.Context, id string, auto bo
 	}
 
 	_, err = ms.nodeClient.MpoolBatchPush(ctx, []*venusTypes.SignedMessage{&signedMsg})
+	ms.saveBroadcastResults([]*types.BroadcastResult{newBroadcastResult(&signedMsg, DefaultNodeName, err)})
 
 	return signedMsg.Cid(), err
 }
 
 func (ms *MessageService) MarkBadMessage(ctx context.Context, id string) (struct{}, error) {
+	if _, err := ms.GetMessageByUid(ctx, id); err != nil {
+		return struct{}{}, err
+	}
 	return ms.repo.MessageRepo().MarkBadMessage(id)
 }
 
+// UnblockMessage move a message blocked by sign policy back to UnFillMsg, it is checked against the policies again
+// when selected, used after the policy relaxed
+func (ms *MessageService) UnblockMessage(ctx context.Context, id string) (struct{}, error) {
+	msg, err := ms.GetMessageByUid(ctx, id)
+	if err != nil {
+		return struct{}{}, err
+	}
+	if msg.State != types.BlockedByPolicyMsg {
+		return struct{}{}, xerrors.Errorf("need BlockedByPolicyMsg got %s", types.MsgStateToString(msg.State))
+	}
+	if err := ms.repo.MessageRepo().UpdateMessageStateByID(id, types.UnFillMsg); err != nil {
+		return struct{}{}, err
+	}
+	if err := ms.messageState.MutatorMessage(id, func(message *types.Message) error {
+		message.State = types.UnFillMsg
+		return nil
+	}); err != nil {
+		return struct{}{}, err
+	}
+	ms.log.Infof("unblock message %s", id)
+
+	return struct{}{}, nil
+}
+
 func (ms *MessageService) RepublishMessage(ctx context.Context, id string) (struct{}, error) {
 	msg, err := ms.GetMessageByUid(ctx, id)
 	if err != nil {
@@ -951,10 +1171,13 @@ func (ms *MessageService) RepublishMessage(ctx context.Context, id string) (stru
 		Message:   msg.UnsignedMessage,
 		Signature: *msg.Signature,
 	}
-	if _, err := ms.nodeClient.MpoolPush(ctx, signedMsg); err != nil {
+	_, err = ms.nodeClient.MpoolPush(ctx, signedMsg)
+	ms.saveBroadcastResults([]*types.BroadcastResult{newBroadcastResult(signedMsg, DefaultNodeName, err)})
+	// the broadcast nodes may accept the message even if the default node rejects it
+	ms.multiNodeToPush(ctx, []*venusTypes.SignedMessage{signedMsg})
+	if err != nil {
 		return struct{}{}, err
 	}
-	ms.multiNodeToPush(ctx, []*venusTypes.SignedMessage{signedMsg})
 
 	return struct{}{}, nil
 }
@@ -975,7 +1198,9 @@ func ToSignedMsg(ctx context.Context, walletCli gateway.IWalletClient, msg *type
 		return venusTypes.SignedMessage{}, xerrors.Errorf("wallet sign failed %s fail %v", msg.ID, err)
 	}
 
+	signedAt := time.Now()
 	msg.Signature = sig
+	msg.SignedAt = &signedAt
 	//state
 	msg.State = types.FillMsg
 
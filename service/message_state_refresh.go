@@ -9,6 +9,7 @@ import (
 	venustypes "github.com/filecoin-project/venus/pkg/types"
 	"github.com/ipfs/go-cid"
 	"golang.org/x/xerrors"
+	"gorm.io/gorm"
 
 	"github.com/filecoin-project/venus-messager/models/repo"
 	"github.com/filecoin-project/venus-messager/types"
@@ -54,7 +55,7 @@ func (ms *MessageService) doRefreshMessageState(ctx context.Context, h *headChan
 		return err
 	}
 
-	applyMsgs, err := ms.processBlockParentMessages(ctx, h.apply)
+	applyMsgs, feeHistories, err := ms.processBlockParentMessages(ctx, h.apply)
 	if err != nil {
 		return xerrors.Errorf("process apply failed %v", err)
 	}
@@ -68,10 +69,12 @@ func (ms *MessageService) doRefreshMessageState(ctx context.Context, h *headChan
 	}
 
 	// update db
-	replaceMsg, err := ms.updateMessageState(ctx, tsKeys, applyMsgs, revertMsgs)
+	replaceMsg, err := ms.updateMessageState(ctx, tsKeys, applyMsgs, revertMsgs, h.revert)
 	if err != nil {
 		return err
 	}
+	ms.saveFeeHistory(feeHistories)
+
 	// update cache
 	for id, msg := range replaceMsg {
 		ms.messageState.SetMessage(id, msg)
@@ -80,6 +83,7 @@ func (ms *MessageService) doRefreshMessageState(ctx context.Context, h *headChan
 	for _, msg := range applyMsgs {
 		if err := ms.messageState.UpdateMessageByCid(msg.cid, func(message *types.Message) error {
 			message.Receipt = msg.receipt
+			message.Fee = msg.fee
 			message.Height = int64(msg.height)
 			message.State = types.OnChainMsg
 			return nil
@@ -91,6 +95,7 @@ func (ms *MessageService) doRefreshMessageState(ctx context.Context, h *headChan
 	for cid := range revertMsgs {
 		if err := ms.messageState.UpdateMessageByCid(cid, func(message *types.Message) error {
 			message.Receipt = &venustypes.MessageReceipt{ExitCode: -1}
+			message.Fee = nil
 			message.Height = 0
 			message.State = types.FillMsg
 			return nil
@@ -118,23 +123,57 @@ func (ms *MessageService) doRefreshMessageState(ctx context.Context, h *headChan
 	return nil
 }
 
-func (ms *MessageService) updateMessageState(ctx context.Context, tsKeys map[abi.ChainEpoch]venustypes.TipSetKey, applyMsgs []pendingMessage, revertMsgs map[cid.Cid]struct{}) (map[string]*types.Message, error) {
+func (ms *MessageService) updateMessageState(ctx context.Context, tsKeys map[abi.ChainEpoch]venustypes.TipSetKey, applyMsgs []pendingMessage, revertMsgs map[cid.Cid]struct{}, revert []*venustypes.TipSet) (map[string]*types.Message, error) {
 	replaceMsg := make(map[string]*types.Message)
 	return replaceMsg, ms.repo.Transaction(func(txRepo repo.TxRepo) error {
+		// messages sent out of messager are saved again if they are applied in another tipset
+		for _, ts := range revert {
+			if err := txRepo.ExternalMessageRepo().DelExternalMessageByHeight(ts.Height()); err != nil {
+				return xerrors.Errorf("delete external message at height %d failed %v", ts.Height(), err)
+			}
+		}
 		for cid := range revertMsgs {
 			if err := txRepo.MessageRepo().UpdateMessageInfoByCid(cid.String(), &venustypes.MessageReceipt{ExitCode: -1},
 				abi.ChainEpoch(0), types.FillMsg, venustypes.EmptyTSK); err != nil {
 				return err
 			}
+			if err := txRepo.MessageRepo().UpdateMessageFeeByCid(cid.String(), nil); err != nil {
+				return err
+			}
 		}
 
 		for _, msg := range applyMsgs {
+			tsKey := tsKeys[msg.height]
 			localMsg, err := txRepo.MessageRepo().GetMessageByFromAndNonce(msg.msg.From, msg.msg.Nonce)
-			if err != nil {
-				ms.log.Warnf("msg not exit in local db maybe address %s send out of messager", msg.msg.From)
+			if err != nil && !xerrors.Is(err, gorm.ErrRecordNotFound) {
+				return xerrors.Errorf("get message of %s by nonce %d failed %v", msg.msg.From, msg.msg.Nonce, err)
+			}
+			if err != nil || localMsg.State == types.UnFillMsg {
+				ms.log.Warnf("msg %s not exit in local db, address %s send it out of messager", msg.cid, msg.msg.From)
+				if err := txRepo.ExternalMessageRepo().SaveExternalMessage(newExternalMessage(msg, tsKey, "")); err != nil {
+					return xerrors.Errorf("save external message %s failed %v", msg.cid, err)
+				}
+				continue
+			}
+			if localMsg.State == types.FillMsg && localMsg.UnsignedCid != nil && *localMsg.UnsignedCid != msg.cid &&
+				!isSameCall(&localMsg.UnsignedMessage, msg.msg) {
+				// another message sent out of messager took the nonce, send the local message again with a new nonce
+				ms.log.Warnf("nonce %d of message %s taken by message %s sent out of messager, re-queue it", msg.msg.Nonce, localMsg.ID, msg.cid)
+				if err := txRepo.ExternalMessageRepo().SaveExternalMessage(newExternalMessage(msg, tsKey, localMsg.ID)); err != nil {
+					return xerrors.Errorf("save external message %s failed %v", msg.cid, err)
+				}
+				if localMsg.SignedExternally() {
+					// can't be signed again with a new nonce
+					failMessage(localMsg, externalConflictReason(msg, false))
+				} else {
+					requeueMessage(localMsg, externalConflictReason(msg, true))
+				}
+				if err := txRepo.MessageRepo().SaveMessage(localMsg); err != nil {
+					return xerrors.Errorf("re-queue message %s failed %v", localMsg.ID, err)
+				}
+				replaceMsg[localMsg.ID] = localMsg
 				continue
 			}
-			tsKey := tsKeys[msg.height]
 			if localMsg.UnsignedCid == nil || *localMsg.UnsignedCid != msg.cid {
 				ms.log.Warnf("replace message old msg cid %s new msg cid %s", localMsg.UnsignedCid, msg.cid)
 				//replace msg
@@ -146,6 +185,7 @@ func (ms *MessageService) updateMessageState(ctx context.Context, tsKeys map[abi
 				localMsg.Receipt = msg.receipt
 				localMsg.Height = int64(msg.height)
 				localMsg.TipSetKey = tsKey
+				localMsg.Fee = msg.fee
 				if err = txRepo.MessageRepo().SaveMessage(localMsg); err != nil {
 					return xerrors.Errorf("update message receipt failed, cid:%s failed:%v", msg.cid.String(), err)
 				}
@@ -154,6 +194,9 @@ func (ms *MessageService) updateMessageState(ctx context.Context, tsKeys map[abi
 				if err = txRepo.MessageRepo().UpdateMessageInfoByCid(msg.cid.String(), msg.receipt, msg.height, types.OnChainMsg, tsKey); err != nil {
 					return xerrors.Errorf("update message receipt failed, cid:%s failed:%v", msg.cid.String(), err)
 				}
+				if err = txRepo.MessageRepo().UpdateMessageFeeByCid(msg.cid.String(), msg.fee); err != nil {
+					return xerrors.Errorf("update message fee failed, cid:%s failed:%v", msg.cid.String(), err)
+				}
 			}
 			delete(revertMsgs, msg.cid)
 		}
@@ -196,41 +239,47 @@ type pendingMessage struct {
 	msg     *venustypes.UnsignedMessage
 	height  abi.ChainEpoch
 	receipt *venustypes.MessageReceipt
+	fee     *types.MsgFee
 }
 
-func (ms *MessageService) processBlockParentMessages(ctx context.Context, apply []*venustypes.TipSet) ([]pendingMessage, error) {
+func (ms *MessageService) processBlockParentMessages(ctx context.Context, apply []*venustypes.TipSet) ([]pendingMessage, []*types.FeeHistory, error) {
 	var applyMsgs []pendingMessage
+	var feeHistories []*types.FeeHistory
 	addrs := ms.addressService.Addresses()
 	for _, ts := range apply {
 		bcid := ts.At(0).Cid()
 		height := ts.Height()
 		msgs, err := ms.nodeClient.ChainGetParentMessages(ctx, bcid)
 		if err != nil {
-			return nil, xerrors.Errorf("got parent message failed %w", err)
+			return nil, nil, xerrors.Errorf("got parent message failed %w", err)
 		}
 
 		receipts, err := ms.nodeClient.ChainGetParentReceipts(ctx, bcid)
 		if err != nil {
-			return nil, xerrors.Errorf("got parent receipt failed %w", err)
+			return nil, nil, xerrors.Errorf("got parent receipt failed %w", err)
 		}
 
 		if len(msgs) != len(receipts) {
-			return nil, xerrors.Errorf("messages not match receipts, %d != %d", len(msgs), len(receipts))
+			return nil, nil, xerrors.Errorf("messages not match receipts, %d != %d", len(msgs), len(receipts))
 		}
 
+		unsignedMsgs := make([]*venustypes.UnsignedMessage, 0, len(msgs))
 		for i := range receipts {
 			msg := msgs[i].Message
+			unsignedMsgs = append(unsignedMsgs, msg)
 			if _, ok := addrs[msg.From]; ok {
 				applyMsgs = append(applyMsgs, pendingMessage{
 					height:  height,
 					receipt: receipts[i],
+					fee:     computeMsgFee(msg, receipts[i], ts.Blocks()[0].ParentBaseFee),
 					msg:     msg,
 					cid:     msg.Cid(),
 				})
 			}
 		}
+		feeHistories = append(feeHistories, newFeeHistory(ts, unsignedMsgs, addrs))
 	}
-	return applyMsgs, nil
+	return applyMsgs, feeHistories, nil
 }
 
 type tipsetFormat struct {
@@ -36,7 +36,7 @@ func TestMessageStateCache(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	msgList, err := msgState.repo.MessageRepo().ListMessage()
+	msgList, err := msgState.repo.MessageRepo().ListMessage("")
 	assert.NoError(t, err)
 	assert.Equal(t, 10, len(msgList))
 
@@ -16,12 +16,18 @@ type ServiceMap map[reflect.Type]interface{}
 func MakeServiceMap(msgService *MessageService,
 	addressService *AddressService,
 	sps *SharedParamsService,
-	nodeService *NodeService) ServiceMap {
+	nodeService *NodeService,
+	policyService *SignPolicyService,
+	auditService *AuditService,
+	limitService *AccountLimitService) ServiceMap {
 	sMap := make(ServiceMap)
 	sMap[reflect.TypeOf(msgService)] = msgService
 	sMap[reflect.TypeOf(addressService)] = addressService
 	sMap[reflect.TypeOf(sps)] = sps
 	sMap[reflect.TypeOf(nodeService)] = nodeService
+	sMap[reflect.TypeOf(policyService)] = policyService
+	sMap[reflect.TypeOf(auditService)] = auditService
+	sMap[reflect.TypeOf(limitService)] = limitService
 	return sMap
 }
 
@@ -32,20 +38,25 @@ func MessagerService() fx.Option {
 		fx.Provide(NewAddressService),
 		fx.Provide(NewSharedParamsService),
 		fx.Provide(NewNodeService),
+		fx.Provide(NewSignPolicyService),
+		fx.Provide(NewAuditService),
+		fx.Provide(NewAccountLimitService),
 		fx.Provide(MakeServiceMap),
 	)
 }
 
-func StartNodeEvents(lc fx.Lifecycle, client *NodeClient, msgService *MessageService, log *log.Logger) *NodeEvents {
+func StartNodeEvents(lc fx.Lifecycle, client *NodeClient, failover *NodeFailover, msgService *MessageService, log *log.Logger) *NodeEvents {
 	nd := &NodeEvents{
 		client:     client,
 		log:        log,
 		msgService: msgService,
+		failover:   failover,
 	}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			go msgService.StartPushMessage(ctx, msgService.cfg.SkipPushMessage)
+			go msgService.StartMpoolWatcher(ctx)
 			go func() {
 				for {
 					if err := nd.listenHeadChangesOnce(ctx); err != nil {
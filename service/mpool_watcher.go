@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// StartMpoolWatcher check filled messages are still in mpool of node every MpoolWatchInterval seconds,
+// missing messages are republished, and messages replaced in mpool are flagged
+func (ms *MessageService) StartMpoolWatcher(ctx context.Context) {
+	if ms.cfg.MpoolWatchInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(ms.cfg.MpoolWatchInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ms.log.Infof("stop mpool watcher")
+			return
+		case <-ticker.C:
+			if err := ms.checkMpool(ctx); err != nil {
+				ms.log.Errorf("check mpool failed %v", err)
+			}
+		}
+	}
+}
+
+func (ms *MessageService) checkMpool(ctx context.Context) error {
+	addrList, err := ms.addressService.ListAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	var filled []*types.Message
+	nonces := make(map[address.Address]uint64)
+	for _, addr := range addrList {
+		msgs, err := ms.repo.MessageRepo().ListFilledMessageByAddress(addr.Addr)
+		if err != nil {
+			return xerrors.Errorf("list filled message of %s failed %v", addr.Addr, err)
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		actor, err := ms.nodeClient.StateGetActor(ctx, addr.Addr, venusTypes.EmptyTSK)
+		if err != nil {
+			ms.log.Warnf("get actor of %s failed %v", addr.Addr, err)
+			continue
+		}
+		nonces[addr.Addr] = actor.Nonce
+		filled = append(filled, msgs...)
+	}
+	if len(filled) == 0 {
+		return nil
+	}
+
+	pending, err := ms.nodeClient.MpoolPending(ctx, venusTypes.EmptyTSK)
+	if err != nil {
+		return xerrors.Errorf("get pending messages failed %v", err)
+	}
+
+	missing, replaced := checkMpoolPresence(filled, pending, nonces)
+	for _, msg := range missing {
+		ms.log.Warnf("message %s nonce %d of %s missing in mpool, republish it", msg.ID, msg.Nonce, msg.From)
+		flag := "mpool: message missing in mpool, republished"
+		if _, err := ms.RepublishMessage(ctx, msg.ID); err != nil {
+			flag = fmt.Sprintf("mpool: message missing in mpool, republish failed %v", err)
+		}
+		ms.flagMessage(msg, flag)
+	}
+	filledByID := make(map[string]*types.Message, len(filled))
+	for _, msg := range filled {
+		filledByID[msg.ID] = msg
+	}
+	for id, c := range replaced {
+		ms.log.Warnf("message %s replaced by %s in mpool", id, c)
+		ms.flagMessage(filledByID[id], fmt.Sprintf("mpool: message replaced by %s", c))
+	}
+	return nil
+}
+
+// checkMpoolPresence return filled messages not in pending and not on chain yet, and messages whose nonce is taken
+// by another message in pending
+func checkMpoolPresence(filled []*types.Message, pending []*venusTypes.SignedMessage, nonces map[address.Address]uint64) ([]*types.Message, map[string]cid.Cid) {
+	type fromNonce struct {
+		from  address.Address
+		nonce uint64
+	}
+	pendingCids := make(map[fromNonce]cid.Cid, len(pending))
+	for _, msg := range pending {
+		pendingCids[fromNonce{from: msg.Message.From, nonce: msg.Message.Nonce}] = msg.Cid()
+	}
+
+	var missing []*types.Message
+	replaced := make(map[string]cid.Cid)
+	for _, msg := range filled {
+		if msg.Nonce < nonces[msg.From] || msg.SignedCid == nil {
+			continue
+		}
+		c, ok := pendingCids[fromNonce{from: msg.From, nonce: msg.Nonce}]
+		if !ok {
+			missing = append(missing, msg)
+			continue
+		}
+		if c != *msg.SignedCid {
+			replaced[msg.ID] = c
+		}
+	}
+	return missing, replaced
+}
+
+// messageFlag return the flag recorded by flagMessage
+func messageFlag(msg *types.Message) string {
+	if msg.Receipt == nil {
+		return ""
+	}
+	return string(msg.Receipt.ReturnValue)
+}
+
+// flagMessage record the flag in return value of receipt, it is overwritten by the receipt when message on chain,
+// nothing is written if the flag is not changed
+func (ms *MessageService) flagMessage(msg *types.Message, flag string) {
+	if messageFlag(msg) == flag {
+		return
+	}
+	id := msg.ID
+	if err := ms.repo.MessageRepo().UpdateReturnValue(id, flag); err != nil {
+		ms.log.Errorf("flag message %s failed %v", id, err)
+		return
+	}
+	err := ms.messageState.MutatorMessage(id, func(message *types.Message) error {
+		if message.Receipt != nil {
+			message.Receipt.ReturnValue = []byte(flag)
+		} else {
+			message.Receipt = &venusTypes.MessageReceipt{ReturnValue: []byte(flag)}
+		}
+		return nil
+	})
+	if err != nil {
+		ms.log.Errorf("update cache of message %s failed %v", id, err)
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestCheckMpoolPresence(t *testing.T) {
+	from, _ := address.NewIDAddress(1001)
+	to, _ := address.NewIDAddress(1002)
+	signed := func(nonce uint64, value int64) *venusTypes.SignedMessage {
+		return &venusTypes.SignedMessage{
+			Message: venusTypes.UnsignedMessage{
+				From:       from,
+				To:         to,
+				Nonce:      nonce,
+				Value:      big.NewInt(value),
+				GasFeeCap:  big.Zero(),
+				GasPremium: big.Zero(),
+			},
+			Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte("sig")},
+		}
+	}
+	filled := func(id string, smsg *venusTypes.SignedMessage) *types.Message {
+		c := smsg.Cid()
+		return &types.Message{ID: id, UnsignedMessage: smsg.Message, SignedCid: &c}
+	}
+
+	onChain := signed(9, 1)
+	inPool := signed(10, 1)
+	evicted := signed(11, 1)
+	replaced := signed(12, 1)
+	replacement := signed(12, 2)
+
+	missing, replacedMsgs := checkMpoolPresence(
+		[]*types.Message{filled("onChain", onChain), filled("inPool", inPool), filled("evicted", evicted), filled("replaced", replaced)},
+		[]*venusTypes.SignedMessage{inPool, replacement},
+		map[address.Address]uint64{from: 10},
+	)
+	assert.Len(t, missing, 1)
+	assert.Equal(t, "evicted", missing[0].ID)
+	assert.Len(t, replacedMsgs, 1)
+	assert.Equal(t, replacement.Cid(), replacedMsgs["replaced"])
+}
+
+func TestMessageFlag(t *testing.T) {
+	msg := &types.Message{}
+	assert.Equal(t, "", messageFlag(msg))
+	msg.Receipt = &venusTypes.MessageReceipt{ReturnValue: []byte("mpool: message missing in mpool, republished")}
+	assert.Equal(t, "mpool: message missing in mpool, republished", messageFlag(msg))
+}
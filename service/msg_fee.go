@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venustypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/filecoin-project/venus/pkg/vm/gas"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// timeout of getting the actor code to resolve a method name
+const actorResolveTimeout = time.Second * 5
+
+// computeMsgFee compute the fee actually paid by msg which executed with baseFee
+func computeMsgFee(msg *venustypes.UnsignedMessage, receipt *venustypes.MessageReceipt, baseFee big.Int) *types.MsgFee {
+	outputs := gas.ComputeGasOutputs(receipt.GasUsed, msg.GasLimit, baseFee, msg.GasFeeCap, msg.GasPremium, true)
+	return &types.MsgFee{
+		BaseFee:            baseFee,
+		BaseFeeBurn:        outputs.BaseFeeBurn,
+		OverEstimationBurn: outputs.OverEstimationBurn,
+		MinerTip:           outputs.MinerTip,
+	}
+}
+
+func (ms *MessageService) GetFeeReport(ctx context.Context, params *types.FeeReportParams) ([]*types.FeeReport, error) {
+	var keyFunc func(msg *types.Message) string
+	calls := make(map[string]actorMethod)
+	switch params.GroupBy {
+	case types.FeeReportByAddress:
+		keyFunc = func(msg *types.Message) string {
+			return msg.From.String()
+		}
+	case types.FeeReportByUser:
+		keyFunc = func(msg *types.Message) string {
+			return msg.FromUser
+		}
+	case types.FeeReportByMethod:
+		// grouped by actor and method first, names are resolved after scanning not to hold the database connection
+		keyFunc = func(msg *types.Message) string {
+			key := msg.To.String() + "/" + strconv.FormatUint(uint64(msg.Method), 10)
+			calls[key] = actorMethod{to: msg.To, method: msg.Method}
+			return key
+		}
+	default:
+		return nil, xerrors.Errorf("unexpected group by %s", params.GroupBy)
+	}
+
+	startHeight, endHeight, err := ms.heightRange(ctx, params.Start, params.End)
+	if err != nil {
+		return nil, err
+	}
+	// messages are counted by the height landed on chain, fees are big int in database so summed here
+	account, _ := scopedAccountFromContext(ctx)
+	agg := newFeeAggregator(keyFunc)
+	if err := ms.repo.MessageRepo().ScanOnChainMessage(account, startHeight, endHeight, func(msg *types.Message) error {
+		agg.add(msg)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if params.GroupBy == types.FeeReportByMethod {
+		methodName := ms.methodNameResolver(ctx)
+		agg.rekey(func(key string) string {
+			call := calls[key]
+			return methodName(call.to, call.method)
+		})
+	}
+
+	return agg.result(), nil
+}
+
+// actorMethod is the method called on an actor
+type actorMethod struct {
+	to     address.Address
+	method abi.MethodNum
+}
+
+// heightRange convert [start, end) to the heights of tipsets produced in it, epochs are aligned to the genesis time
+// even there are null rounds
+func (ms *MessageService) heightRange(ctx context.Context, start, end time.Time) (abi.ChainEpoch, abi.ChainEpoch, error) {
+	if end.Before(start) {
+		return 0, 0, xerrors.Errorf("end time %v before start time %v", end, start)
+	}
+	head, err := ms.nodeClient.ChainHead(ctx)
+	if err != nil {
+		return 0, 0, xerrors.Errorf("get chain head failed %v", err)
+	}
+	if head.Height() <= 0 {
+		return 0, 0, xerrors.Errorf("chain not started")
+	}
+	genesis, err := ms.nodeClient.ChainGetTipSetByHeight(ctx, 0, head.Key())
+	if err != nil {
+		return 0, 0, xerrors.Errorf("get genesis failed %v", err)
+	}
+	blockDelay := int64(head.MinTimestamp()-genesis.MinTimestamp()) / int64(head.Height())
+	if blockDelay <= 0 {
+		return 0, 0, xerrors.Errorf("invalid block delay %d", blockDelay)
+	}
+
+	// the first epoch whose timestamp not before t
+	epochAt := func(t time.Time) abi.ChainEpoch {
+		elapsed := t.Unix() - int64(genesis.MinTimestamp())
+		if elapsed <= 0 {
+			return 0
+		}
+		return abi.ChainEpoch((elapsed + blockDelay - 1) / blockDelay)
+	}
+	return epochAt(start), epochAt(end), nil
+}
+
+// feeAggregator sum the fee of messages with the same key, messages whose fee is unknown are skipped
+type feeAggregator struct {
+	keyFunc func(msg *types.Message) string
+	reports map[string]*types.FeeReport
+}
+
+func newFeeAggregator(keyFunc func(msg *types.Message) string) *feeAggregator {
+	return &feeAggregator{keyFunc: keyFunc, reports: make(map[string]*types.FeeReport)}
+}
+
+func (agg *feeAggregator) add(msg *types.Message) {
+	if msg.Fee == nil {
+		return
+	}
+	key := agg.keyFunc(msg)
+	report, ok := agg.reports[key]
+	if !ok {
+		report = &types.FeeReport{
+			Key:                key,
+			BaseFeeBurn:        big.Zero(),
+			OverEstimationBurn: big.Zero(),
+			MinerTip:           big.Zero(),
+			TotalFee:           big.Zero(),
+		}
+		agg.reports[key] = report
+	}
+	report.MsgCount++
+	if msg.Receipt != nil {
+		report.GasUsed += msg.Receipt.GasUsed
+	}
+	report.BaseFeeBurn = big.Add(report.BaseFeeBurn, msg.Fee.BaseFeeBurn)
+	report.OverEstimationBurn = big.Add(report.OverEstimationBurn, msg.Fee.OverEstimationBurn)
+	report.MinerTip = big.Add(report.MinerTip, msg.Fee.MinerTip)
+	report.TotalFee = big.Add(report.TotalFee, msg.Fee.TotalFee())
+}
+
+// rekey group the reports again by keyFunc of their keys, reports with the same new key are merged
+func (agg *feeAggregator) rekey(keyFunc func(key string) string) {
+	reports := make(map[string]*types.FeeReport, len(agg.reports))
+	for key, report := range agg.reports {
+		newKey := keyFunc(key)
+		merged, ok := reports[newKey]
+		if !ok {
+			report.Key = newKey
+			reports[newKey] = report
+			continue
+		}
+		merged.MsgCount += report.MsgCount
+		merged.GasUsed += report.GasUsed
+		merged.BaseFeeBurn = big.Add(merged.BaseFeeBurn, report.BaseFeeBurn)
+		merged.OverEstimationBurn = big.Add(merged.OverEstimationBurn, report.OverEstimationBurn)
+		merged.MinerTip = big.Add(merged.MinerTip, report.MinerTip)
+		merged.TotalFee = big.Add(merged.TotalFee, report.TotalFee)
+	}
+	agg.reports = reports
+}
+
+func (agg *feeAggregator) result() []*types.FeeReport {
+	result := make([]*types.FeeReport, 0, len(agg.reports))
+	for _, report := range agg.reports {
+		result = append(result, report)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TotalFee.GreaterThan(result[j].TotalFee)
+	})
+
+	return result
+}
+
+// methodNameResolver return a function to get the method name of message, actor codes are cached in one report, a
+// slow node only makes the method reported by number
+func (ms *MessageService) methodNameResolver(ctx context.Context) func(to address.Address, method abi.MethodNum) string {
+	codes := make(map[address.Address]cid.Cid)
+	return func(to address.Address, method abi.MethodNum) string {
+		code, ok := codes[to]
+		if !ok {
+			callCtx, cancel := context.WithTimeout(ctx, actorResolveTimeout)
+			actor, err := ms.nodeClient.StateGetActor(callCtx, to, venustypes.EmptyTSK)
+			cancel()
+			if err != nil {
+				ms.log.Warnf("get actor %s failed %v", to, err)
+			} else {
+				code = actor.Code
+			}
+			codes[to] = code
+		}
+		if methodMeta, found := types.MethodsMap[code][method]; found {
+			return methodMeta.Name
+		}
+		return strconv.FormatUint(uint64(method), 10)
+	}
+}
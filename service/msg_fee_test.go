@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin5 "github.com/filecoin-project/specs-actors/v5/actors/builtin"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestComputeMsgFee(t *testing.T) {
+	msg := &venusTypes.UnsignedMessage{
+		GasLimit:   1000,
+		GasFeeCap:  big.NewInt(300),
+		GasPremium: big.NewInt(50),
+	}
+	fee := computeMsgFee(msg, &venusTypes.MessageReceipt{GasUsed: 1000}, big.NewInt(100))
+	assert.Equal(t, big.NewInt(100), fee.BaseFee)
+	assert.Equal(t, big.NewInt(100*1000), fee.BaseFeeBurn)
+	assert.Equal(t, big.Zero(), fee.OverEstimationBurn)
+	assert.Equal(t, big.NewInt(50*1000), fee.MinerTip)
+	assert.Equal(t, big.NewInt(150*1000), fee.TotalFee())
+
+	// burn part of the gas over estimated
+	fee = computeMsgFee(msg, &venusTypes.MessageReceipt{GasUsed: 500}, big.NewInt(100))
+	assert.Equal(t, big.NewInt(100*500), fee.BaseFeeBurn)
+	assert.True(t, fee.OverEstimationBurn.GreaterThan(big.Zero()))
+	assert.Equal(t, big.NewInt(50*1000), fee.MinerTip)
+}
+
+func TestAggregateFee(t *testing.T) {
+	newMsg := func(user string, tip int64) *types.Message {
+		return &types.Message{
+			FromUser: user,
+			Receipt:  &venusTypes.MessageReceipt{GasUsed: 10},
+			Fee: &types.MsgFee{
+				BaseFee:            big.NewInt(100),
+				BaseFeeBurn:        big.NewInt(1000),
+				OverEstimationBurn: big.Zero(),
+				MinerTip:           big.NewInt(tip),
+			},
+		}
+	}
+	msgs := []*types.Message{newMsg("a", 10), newMsg("b", 100), newMsg("a", 20), {FromUser: "a"}}
+
+	agg := newFeeAggregator(func(msg *types.Message) string {
+		return msg.FromUser
+	})
+	for _, msg := range msgs {
+		agg.add(msg)
+	}
+	reports := agg.result()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "a", reports[0].Key)
+	assert.Equal(t, 2, reports[0].MsgCount)
+	assert.Equal(t, int64(20), reports[0].GasUsed)
+	assert.Equal(t, big.NewInt(30), reports[0].MinerTip)
+	assert.Equal(t, big.NewInt(2030), reports[0].TotalFee)
+	assert.Equal(t, "b", reports[1].Key)
+	assert.Equal(t, big.NewInt(1100), reports[1].TotalFee)
+
+	// reports resolved to the same key are merged
+	agg.rekey(func(key string) string {
+		return "all"
+	})
+	reports = agg.result()
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "all", reports[0].Key)
+	assert.Equal(t, 3, reports[0].MsgCount)
+	assert.Equal(t, int64(30), reports[0].GasUsed)
+	assert.Equal(t, big.NewInt(130), reports[0].MinerTip)
+	assert.Equal(t, big.NewInt(3130), reports[0].TotalFee)
+}
+
+func TestFeeReportByMethod(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "fee_report.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("fee_report.db"))
+		assert.NoError(t, os.Remove("fee_report.db-shm"))
+		assert.NoError(t, os.Remove("fee_report.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	fee := &types.MsgFee{
+		BaseFee:            big.NewInt(100),
+		BaseFeeBurn:        big.NewInt(1000),
+		OverEstimationBurn: big.Zero(),
+		MinerTip:           big.NewInt(10),
+	}
+	msgs := models.NewSignedMessages(3)
+	for i, method := range []abi.MethodNum{0, 0, 99} {
+		msgs[i].Method = method
+		msgs[i].State = types.OnChainMsg
+		msgs[i].Height = 10
+		assert.NoError(t, db.MessageRepo().CreateMessage(msgs[i]))
+		assert.NoError(t, db.MessageRepo().UpdateMessageFeeByCid(msgs[i].UnsignedCid.String(), fee))
+	}
+
+	genesisTs := uint64(1600000000)
+	genesis := newMockTipSetAt(t, 0, genesisTs)
+	head := newMockTipSetAt(t, 100, genesisTs+100*30)
+	ms := &MessageService{
+		repo: db,
+		log:  log.New(),
+		nodeClient: &NodeClient{
+			ChainHead: func(context.Context) (*venusTypes.TipSet, error) {
+				return head, nil
+			},
+			ChainGetTipSetByHeight: func(context.Context, abi.ChainEpoch, venusTypes.TipSetKey) (*venusTypes.TipSet, error) {
+				return genesis, nil
+			},
+			StateGetActor: func(ctx context.Context, addr address.Address, tsk venusTypes.TipSetKey) (*venusTypes.Actor, error) {
+				_, ok := ctx.Deadline()
+				assert.True(t, ok)
+				// blocks if the rows scanned still hold the only connection of sqlite
+				_, err := db.MessageRepo().GetMessageByUid(msgs[0].ID)
+				assert.NoError(t, err)
+				return &venusTypes.Actor{Code: builtin5.AccountActorCodeID}, nil
+			},
+		},
+	}
+
+	reports, err := ms.GetFeeReport(context.Background(), &types.FeeReportParams{
+		GroupBy: types.FeeReportByMethod,
+		Start:   time.Unix(int64(genesisTs), 0),
+		End:     time.Unix(int64(genesisTs)+3000, 0),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "Send", reports[0].Key)
+	assert.Equal(t, 2, reports[0].MsgCount)
+	assert.Equal(t, big.NewInt(2020), reports[0].TotalFee)
+	assert.Equal(t, "99", reports[1].Key)
+	assert.Equal(t, 1, reports[1].MsgCount)
+}
+
+func TestHeightRange(t *testing.T) {
+	genesisTs := uint64(1600000000)
+	genesis := newMockTipSetAt(t, 0, genesisTs)
+	// null rounds not affect the block delay
+	head := newMockTipSetAt(t, 100, genesisTs+100*30)
+	ms := &MessageService{
+		nodeClient: &NodeClient{
+			ChainHead: func(context.Context) (*venusTypes.TipSet, error) {
+				return head, nil
+			},
+			ChainGetTipSetByHeight: func(context.Context, abi.ChainEpoch, venusTypes.TipSetKey) (*venusTypes.TipSet, error) {
+				return genesis, nil
+			},
+		},
+	}
+	ctx := context.Background()
+	at := func(sec int64) time.Time {
+		return time.Unix(int64(genesisTs)+sec, 0)
+	}
+
+	start, end, err := ms.heightRange(ctx, at(-100), at(300))
+	assert.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(0), start)
+	assert.Equal(t, abi.ChainEpoch(10), end)
+
+	start, end, err = ms.heightRange(ctx, at(301), at(330))
+	assert.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(11), start)
+	assert.Equal(t, abi.ChainEpoch(11), end)
+
+	_, _, err = ms.heightRange(ctx, at(300), at(0))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus-messager/config"
 	"github.com/filecoin-project/venus/app/submodule/apitypes"
@@ -27,6 +28,7 @@ type NodeClient struct {
 	ChainNotify            func(context.Context) (<-chan []*chain.HeadChange, error)
 	ChainHead              func(context.Context) (*types.TipSet, error)
 	ChainGetTipSet         func(context.Context, types.TipSetKey) (*types.TipSet, error)
+	ChainGetTipSetByHeight func(context.Context, abi.ChainEpoch, types.TipSetKey) (*types.TipSet, error)
 	ChainGetBlock          func(context.Context, cid.Cid) (*types.BlockHeader, error)
 	ChainGetBlockMessages  func(context.Context, cid.Cid) (*apitypes.BlockMessages, error)
 	ChainGetParentMessages func(ctx context.Context, bcid cid.Cid) ([]apitypes.Message, error)
@@ -41,6 +43,7 @@ type NodeClient struct {
 	GasEstimateGasLimit        func(ctx context.Context, msgIn *types.UnsignedMessage, tsk types.TipSetKey) (int64, error)
 	GasBatchEstimateMessageGas func(ctx context.Context, estimateMessages []*EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*EstimateResult, error)
 
+	MpoolPending   func(context.Context, types.TipSetKey) ([]*types.SignedMessage, error)
 	MpoolPush      func(context.Context, *types.SignedMessage) (cid.Cid, error)
 	MpoolBatchPush func(context.Context, []*types.SignedMessage) ([]cid.Cid, error)
 
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"go.uber.org/fx"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+)
+
+const (
+	// PrimaryNodeName is the name of the node configured in config file
+	PrimaryNodeName = "primary"
+
+	nodeHealthCheckInterval = time.Second * 10
+	nodeHealthCheckTimeout  = time.Second * 5
+	// switch to another node after the current node fails the health check so many times in a row
+	nodeMaxFailures = 3
+	// the connection of the node switched from is closed after the period, so calls sent to it can finish
+	nodeCloseGracePeriod = time.Minute
+	// max interval to try switching back to the primary node, the interval doubles after each failed try
+	nodeMaxFailbackInterval = time.Minute * 5
+)
+
+type nodeDialer func(ctx context.Context, cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error)
+
+type nodeConn struct {
+	name   string
+	client *NodeClient
+	closer jsonrpc.ClientCloser
+	// false if failed to connect, all calls of client return error
+	connected bool
+}
+
+// NodeFailover keep a connection to a healthy node among the configured node and the full nodes in database,
+// the client returned by Client always calls the current node, so head events, estimation and state queries
+// go on when the primary node fails
+type NodeFailover struct {
+	log     *log.Logger
+	primary *config.NodeConfig
+	repo    repo.Repo
+	dial    nodeDialer
+
+	lk       sync.RWMutex
+	active   *nodeConn
+	switchCh chan struct{}
+
+	client     *NodeClient
+	closeDelay time.Duration
+}
+
+func NewNodeFailover(ctx context.Context, cfg *config.NodeConfig, logger *log.Logger) (*NodeFailover, error) {
+	return newNodeFailover(ctx, cfg, logger, NewNodeClient)
+}
+
+// newNodeFailover connect to the primary node, if it is unreachable, the best node is chosen by StartNodeFailover
+// when the full nodes in database are known
+func newNodeFailover(ctx context.Context, cfg *config.NodeConfig, logger *log.Logger, dial nodeDialer) (*NodeFailover, error) {
+	active := &nodeConn{name: PrimaryNodeName, connected: true}
+	var err error
+	active.client, active.closer, err = dial(ctx, cfg)
+	if err != nil {
+		logger.Errorf("connect to primary node failed %v", err)
+		active = &nodeConn{name: PrimaryNodeName, client: unavailableNodeClient(err), closer: func() {}}
+	}
+	nf := &NodeFailover{
+		log:        logger,
+		primary:    cfg,
+		dial:       dial,
+		active:     active,
+		switchCh:   make(chan struct{}),
+		closeDelay: nodeCloseGracePeriod,
+	}
+	nf.client = nf.proxy()
+	return nf, nil
+}
+
+// unavailableNodeClient return a client whose methods all return err
+func unavailableNodeClient(err error) *NodeClient {
+	var res NodeClient
+	rv := reflect.ValueOf(&res).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		fnType := field.Type()
+		field.Set(reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+			return errorValues(fnType, xerrors.Errorf("node not connected: %v", err))
+		}))
+	}
+	return &res
+}
+
+// errorValues return zero values and err as the results of function type
+func errorValues(fnType reflect.Type, err error) []reflect.Value {
+	results := make([]reflect.Value, fnType.NumOut())
+	for i := 0; i < fnType.NumOut()-1; i++ {
+		results[i] = reflect.Zero(fnType.Out(i))
+	}
+	results[fnType.NumOut()-1] = reflect.ValueOf(&err).Elem()
+	return results
+}
+
+// proxy build a client whose methods call the same method of the current node
+func (nf *NodeFailover) proxy() *NodeClient {
+	var res NodeClient
+	rv := reflect.ValueOf(&res).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		idx := i
+		field := rv.Field(i)
+		field.Set(reflect.MakeFunc(field.Type(), func(args []reflect.Value) []reflect.Value {
+			current := reflect.ValueOf(nf.current().client).Elem().Field(idx)
+			return current.Call(args)
+		}))
+	}
+	return &res
+}
+
+// Client return the client which follows the current node
+func (nf *NodeFailover) Client() *NodeClient {
+	return nf.client
+}
+
+// ActiveNode return the name of the current node
+func (nf *NodeFailover) ActiveNode() string {
+	return nf.current().name
+}
+
+func (nf *NodeFailover) current() *nodeConn {
+	nf.lk.RLock()
+	defer nf.lk.RUnlock()
+	return nf.active
+}
+
+// switched return a channel which is closed when the current node switched
+func (nf *NodeFailover) switched() <-chan struct{} {
+	nf.lk.RLock()
+	defer nf.lk.RUnlock()
+	return nf.switchCh
+}
+
+func (nf *NodeFailover) switchTo(conn *nodeConn) {
+	nf.lk.Lock()
+	old := nf.active
+	nf.active = conn
+	close(nf.switchCh)
+	nf.switchCh = make(chan struct{})
+	nf.lk.Unlock()
+
+	nf.log.Warnf("switch node from %s to %s", old.name, conn.name)
+	nf.closeLater(old)
+}
+
+// closeLater close the connection after the grace period, so that calls already sent to it can finish
+func (nf *NodeFailover) closeLater(conn *nodeConn) {
+	if nf.closeDelay <= 0 {
+		conn.closer()
+		return
+	}
+	time.AfterFunc(nf.closeDelay, conn.closer)
+}
+
+func (nf *NodeFailover) Close() {
+	nf.current().closer()
+}
+
+func (nf *NodeFailover) checkHealth(ctx context.Context, client *NodeClient) (*venusTypes.TipSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, nodeHealthCheckTimeout)
+	defer cancel()
+
+	head, err := client.ChainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, xerrors.New("empty chain head")
+	}
+	return head, nil
+}
+
+func (nf *NodeFailover) run(ctx context.Context) {
+	ticker := time.NewTicker(nodeHealthCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	// try switching back to the primary node with backoff, instead of dialing it every tick
+	failbackInterval := nodeHealthCheckInterval
+	var failbackAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := nf.current()
+		if _, err := nf.checkHealth(ctx, current.client); err != nil {
+			failures++
+			nf.log.Warnf("node %s health check failed %d times: %v", current.name, failures, err)
+			if failures >= nodeMaxFailures && nf.failover(ctx) {
+				failures = 0
+				failbackInterval = nodeHealthCheckInterval
+				failbackAt = time.Now().Add(failbackInterval)
+			}
+			continue
+		}
+
+		failures = 0
+		if current.name != PrimaryNodeName && !time.Now().Before(failbackAt) {
+			if !nf.failback(ctx) {
+				failbackInterval *= 2
+				if failbackInterval > nodeMaxFailbackInterval {
+					failbackInterval = nodeMaxFailbackInterval
+				}
+			}
+			failbackAt = time.Now().Add(failbackInterval)
+		}
+	}
+}
+
+// failover switch to the healthy node with the highest head among the configured node and the full nodes in database
+func (nf *NodeFailover) failover(ctx context.Context) bool {
+	candidates := map[string]*config.NodeConfig{PrimaryNodeName: nf.primary}
+	if nf.repo != nil {
+		nodeList, err := nf.repo.NodeRepo().ListNode()
+		if err != nil {
+			nf.log.Errorf("list node failed %v", err)
+		}
+		for _, node := range filterNodeByRole(nodeList, types.ChainRole) {
+			candidates[node.Name] = &config.NodeConfig{Url: node.URL, Token: node.Token}
+		}
+	}
+	return nf.switchToBest(ctx, candidates)
+}
+
+// switchToBest switch to the healthy candidate with the highest head, return false if no candidate is healthy
+func (nf *NodeFailover) switchToBest(ctx context.Context, candidates map[string]*config.NodeConfig) bool {
+	current := nf.current()
+	currentName := current.name
+	var best *nodeConn
+	var bestHeight int64
+	for name, cfg := range candidates {
+		// the node not connected is dialed again
+		if name == currentName && current.connected {
+			continue
+		}
+		client, closer, err := nf.dial(ctx, cfg)
+		if err != nil {
+			nf.log.Warnf("connect to node %s failed %v", name, err)
+			continue
+		}
+		head, err := nf.checkHealth(ctx, client)
+		if err != nil {
+			nf.log.Warnf("node %s health check failed %v", name, err)
+			closer()
+			continue
+		}
+		height := int64(head.Height())
+		// prefer the primary node when heights are equal
+		if best == nil || height > bestHeight || (height == bestHeight && name == PrimaryNodeName) {
+			if best != nil {
+				best.closer()
+			}
+			best, bestHeight = &nodeConn{name: name, client: client, closer: closer, connected: true}, height
+			continue
+		}
+		closer()
+	}
+
+	if best == nil {
+		nf.log.Errorf("no healthy node to switch to, keep using %s", currentName)
+		return false
+	}
+	nf.switchTo(best)
+	return true
+}
+
+// failback switch to the primary node once it is healthy again, return false if the primary node is not healthy
+func (nf *NodeFailover) failback(ctx context.Context) bool {
+	client, closer, err := nf.dial(ctx, nf.primary)
+	if err != nil {
+		return false
+	}
+	if _, err := nf.checkHealth(ctx, client); err != nil {
+		closer()
+		return false
+	}
+	nf.switchTo(&nodeConn{name: PrimaryNodeName, client: client, closer: closer, connected: true})
+	return true
+}
+
+// StartNodeFailover switch to the best node at once if the primary node is unhealthy, so that services constructed
+// later start on a healthy node, then check the health of current node in background
+func StartNodeFailover(lc fx.Lifecycle, nf *NodeFailover, repo repo.Repo) error {
+	nf.repo = repo
+	if _, err := nf.checkHealth(context.Background(), nf.current().client); err != nil {
+		nf.log.Warnf("primary node is unhealthy %v, choose another node", err)
+		if !nf.failover(context.Background()) {
+			return xerrors.Errorf("no healthy node to start with, primary node: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go nf.run(ctx)
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+)
+
+type mockNode struct {
+	head   *types.TipSet
+	closed bool
+}
+
+func newMockTipSet(t *testing.T, height abi.ChainEpoch) *types.TipSet {
+	return newMockTipSetAt(t, height, 0)
+}
+
+func newMockTipSetAt(t *testing.T, height abi.ChainEpoch, timestamp uint64) *types.TipSet {
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	c, err := abi.CidBuilder.Sum([]byte("mock"))
+	require.NoError(t, err)
+	ts, err := types.NewTipSet(&types.BlockHeader{
+		Miner:                 miner,
+		Height:                height,
+		Timestamp:             timestamp,
+		ParentWeight:          big.Zero(),
+		ParentBaseFee:         big.Zero(),
+		ParentStateRoot:       c,
+		ParentMessageReceipts: c,
+		Messages:              c,
+	})
+	require.NoError(t, err)
+	return ts
+}
+
+func TestNodeFailover(t *testing.T) {
+	ctx := context.Background()
+	nodes := map[string]*mockNode{
+		"primary": {head: newMockTipSet(t, 10)},
+	}
+	dial := func(ctx context.Context, cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error) {
+		node, ok := nodes[cfg.Url]
+		if !ok {
+			return nil, nil, xerrors.Errorf("node %s not found", cfg.Url)
+		}
+		node.closed = false
+		return &NodeClient{
+			ChainHead: func(ctx context.Context) (*types.TipSet, error) {
+				if node.head == nil {
+					return nil, xerrors.New("node down")
+				}
+				return node.head, nil
+			},
+		}, func() {
+			node.closed = true
+		}, nil
+	}
+
+	nf, err := newNodeFailover(ctx, &config.NodeConfig{Url: "primary"}, log.New(), dial)
+	require.NoError(t, err)
+	client := nf.Client()
+
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(10), head.Height())
+
+	// no other node, keep the primary node
+	nodes["primary"].head = nil
+	assert.False(t, nf.failover(ctx))
+	assert.Equal(t, PrimaryNodeName, nf.ActiveNode())
+
+	// switch to the highest healthy node
+	nodes["node1"] = &mockNode{head: newMockTipSet(t, 11)}
+	nodes["node2"] = &mockNode{head: newMockTipSet(t, 12)}
+	nodes["node3"] = &mockNode{}
+	switched := nf.switched()
+	require.True(t, nf.switchToBest(ctx, map[string]*config.NodeConfig{
+		PrimaryNodeName: nf.primary,
+		"node1":         {Url: "node1"},
+		"node2":         {Url: "node2"},
+		"node3":         {Url: "node3"},
+		"node4":         {Url: "node4"},
+	}))
+	assert.Equal(t, "node2", nf.ActiveNode())
+	// the node switched from is closed after the grace period
+	assert.False(t, nodes["primary"].closed)
+	assert.True(t, nodes["node1"].closed)
+	assert.False(t, nodes["node2"].closed)
+	select {
+	case <-switched:
+	default:
+		t.Fatal("expect switched channel closed")
+	}
+
+	head, err = client.ChainHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(12), head.Height())
+
+	// switch back to the primary node once it is healthy
+	nf.closeDelay = 0
+	assert.False(t, nf.failback(ctx))
+	assert.Equal(t, "node2", nf.ActiveNode())
+	nodes["primary"].head = newMockTipSet(t, 12)
+	assert.True(t, nf.failback(ctx))
+	assert.Equal(t, PrimaryNodeName, nf.ActiveNode())
+	assert.True(t, nodes["node2"].closed)
+
+	// start with the best node if the primary node is unreachable
+	delete(nodes, "primary")
+	nf, err = newNodeFailover(ctx, &config.NodeConfig{Url: "primary"}, log.New(), dial)
+	require.NoError(t, err)
+	_, err = nf.Client().ChainHead(ctx)
+	assert.Error(t, err)
+	require.True(t, nf.switchToBest(ctx, map[string]*config.NodeConfig{
+		"node1": {Url: "node1"},
+		"node2": {Url: "node2"},
+	}))
+	assert.Equal(t, "node2", nf.ActiveNode())
+	head, err = nf.Client().ChainHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(12), head.Height())
+}
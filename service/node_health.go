@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"go.uber.org/fx"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// node lags behind the best head more than this is treated as unhealthy
+const nodeMaxLag = 5
+
+// nodeHealthMonitor keep the latest health of the nodes in database
+type nodeHealthMonitor struct {
+	lk     sync.RWMutex
+	health map[string]*types.NodeHealth
+}
+
+func newNodeHealthMonitor() *nodeHealthMonitor {
+	return &nodeHealthMonitor{health: make(map[string]*types.NodeHealth)}
+}
+
+func (hm *nodeHealthMonitor) get(name string) *types.NodeHealth {
+	hm.lk.RLock()
+	defer hm.lk.RUnlock()
+	if health, ok := hm.health[name]; ok {
+		cp := *health
+		return &cp
+	}
+	return nil
+}
+
+// healthy return false if the node is unreachable or lagging in the latest check, node not checked yet is treated as healthy
+func (hm *nodeHealthMonitor) healthy(name string) bool {
+	health := hm.get(name)
+	return health == nil || health.Healthy
+}
+
+func (hm *nodeHealthMonitor) update(health map[string]*types.NodeHealth) {
+	hm.lk.Lock()
+	defer hm.lk.Unlock()
+	hm.health = health
+}
+
+func (hm *nodeHealthMonitor) remove(name string) {
+	hm.lk.Lock()
+	defer hm.lk.Unlock()
+	delete(hm.health, name)
+}
+
+// evaluateNodeHealth fill the lag of nodes behind the best head, and mark nodes unreachable or lagging as unhealthy
+func evaluateNodeHealth(health map[string]*types.NodeHealth, bestHeight abi.ChainEpoch) {
+	for _, h := range health {
+		if h.Reachable && h.Height > bestHeight {
+			bestHeight = h.Height
+		}
+	}
+	for _, h := range health {
+		if !h.Reachable {
+			h.Lag = 0
+			h.Healthy = false
+			continue
+		}
+		h.Lag = int64(bestHeight - h.Height)
+		h.Healthy = h.Lag <= nodeMaxLag
+	}
+}
+
+// checkNodeHealth probe the node through the connection of pool
+func checkNodeHealth(ctx context.Context, cli *NodeClient) *types.NodeHealth {
+	health := &types.NodeHealth{CheckedAt: time.Now()}
+	ctx, cancel := context.WithTimeout(ctx, nodeHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	head, err := cli.ChainHead(ctx)
+	health.Latency = time.Since(start)
+	if err != nil {
+		health.LastError = err.Error()
+		return health
+	}
+	health.Reachable = true
+	health.Height = head.Height()
+	return health
+}
+
+// checkNodesHealth check all nodes in database, the head of the chain node used by messager also counts for the best head
+func (ns *NodeService) checkNodesHealth(ctx context.Context, client *NodeClient) {
+	nodeList, err := ns.repo.NodeRepo().ListNode()
+	if err != nil {
+		ns.log.Errorf("list node failed %v", err)
+		return
+	}
+
+	var bestHeight abi.ChainEpoch
+	headCtx, cancel := context.WithTimeout(ctx, nodeHealthCheckTimeout)
+	if head, err := client.ChainHead(headCtx); err == nil {
+		bestHeight = head.Height()
+	}
+	cancel()
+
+	ns.pool.refresh(nodeList)
+	now := time.Now()
+	connected := make(map[string]*NodeClient, len(nodeList))
+	for _, nc := range ns.pool.clients(now) {
+		connected[nc.name] = nc.cli
+	}
+
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	health := make(map[string]*types.NodeHealth, len(nodeList))
+	for _, node := range nodeList {
+		cli, ok := connected[node.Name]
+		if !ok {
+			// the node is reconnected by pool after backoff
+			health[node.Name] = &types.NodeHealth{CheckedAt: now, LastError: ns.pool.lastError(node.Name)}
+			continue
+		}
+		wg.Add(1)
+		go func(name string, cli *NodeClient) {
+			defer wg.Done()
+			h := checkNodeHealth(ctx, cli)
+			if !h.Reachable {
+				ns.pool.reset(name, xerrors.New(h.LastError), time.Now())
+			}
+			lk.Lock()
+			health[name] = h
+			lk.Unlock()
+		}(node.Name, cli)
+	}
+	wg.Wait()
+
+	evaluateNodeHealth(health, bestHeight)
+	for name, h := range health {
+		if !h.Healthy {
+			ns.log.Warnf("node %s unhealthy, reachable %v, height %d, lag %d, error: %s", name, h.Reachable, h.Height, h.Lag, h.LastError)
+		}
+	}
+	ns.health.update(health)
+}
+
+func StartNodeHealthMonitor(lc fx.Lifecycle, ns *NodeService, client *NodeClient) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				ticker := time.NewTicker(nodeHealthCheckInterval)
+				defer ticker.Stop()
+				for {
+					ns.checkNodesHealth(ctx, client)
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+			ns.pool.close()
+			return nil
+		},
+	})
+}
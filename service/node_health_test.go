@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+)
+
+func TestEvaluateNodeHealth(t *testing.T) {
+	health := map[string]*types.NodeHealth{
+		"best":     {Reachable: true, Height: 100},
+		"lagging":  {Reachable: true, Height: 100 - nodeMaxLag - 1},
+		"behind":   {Reachable: true, Height: 100 - nodeMaxLag},
+		"unreach":  {Reachable: false, LastError: "connection refused"},
+		"inferior": {Reachable: true, Height: 98},
+	}
+	evaluateNodeHealth(health, 90)
+
+	assert.True(t, health["best"].Healthy)
+	assert.Equal(t, int64(0), health["best"].Lag)
+	assert.True(t, health["behind"].Healthy)
+	assert.Equal(t, int64(nodeMaxLag), health["behind"].Lag)
+	assert.False(t, health["lagging"].Healthy)
+	assert.Equal(t, int64(nodeMaxLag+1), health["lagging"].Lag)
+	assert.False(t, health["unreach"].Healthy)
+	assert.True(t, health["inferior"].Healthy)
+
+	// the head of chain node counts for the best head
+	evaluateNodeHealth(health, 110)
+	assert.False(t, health["best"].Healthy)
+	assert.Equal(t, int64(10), health["best"].Lag)
+
+	hm := newNodeHealthMonitor()
+	assert.True(t, hm.healthy("best"))
+	hm.update(health)
+	assert.False(t, hm.healthy("unreach"))
+	assert.True(t, hm.healthy("unknown"))
+	assert.Equal(t, "connection refused", hm.get("unreach").LastError)
+	hm.remove("unreach")
+	assert.True(t, hm.healthy("unreach"))
+}
+
+func TestCheckNodeHealth(t *testing.T) {
+	ctx := context.Background()
+	ts := newMockTipSet(t, 10)
+	health := checkNodeHealth(ctx, &NodeClient{
+		ChainHead: func(ctx context.Context) (*venusTypes.TipSet, error) {
+			return ts, nil
+		},
+	})
+	assert.True(t, health.Reachable)
+	assert.Equal(t, abi.ChainEpoch(10), health.Height)
+
+	health = checkNodeHealth(ctx, &NodeClient{
+		ChainHead: func(ctx context.Context) (*venusTypes.TipSet, error) {
+			return nil, xerrors.New("broken pipe")
+		},
+	})
+	assert.False(t, health.Reachable)
+	assert.Equal(t, "broken pipe", health.LastError)
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const (
+	// timeout of pushing messages to one broadcast node
+	nodePushTimeout = time.Second * 30
+	// timeout of connecting one node
+	nodeDialTimeout = time.Second * 10
+
+	nodeReconnectMinBackoff = time.Second * 5
+	nodeReconnectMaxBackoff = time.Minute * 5
+)
+
+type pooledNode struct {
+	node   *types.Node
+	client *NodeClient
+	closer jsonrpc.ClientCloser
+
+	failures  int
+	nextRetry time.Time
+	dialing   bool
+	// the latest error of connecting or using the node
+	lastErr string
+}
+
+// nodeClientPool keep long-lived connections to the nodes in database, connection failed is retried with exponential backoff
+type nodeClientPool struct {
+	log  *log.Logger
+	dial nodeDialer
+	// connections live as long as the context of dial, it is canceled when the pool closed
+	ctx    context.Context
+	cancel context.CancelFunc
+	// connections replaced or broken are closed after the delay, they may be still used by other goroutines
+	closeDelay time.Duration
+
+	lk    sync.Mutex
+	nodes map[string]*pooledNode
+}
+
+func newNodeClientPool(logger *log.Logger, dial nodeDialer) *nodeClientPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &nodeClientPool{
+		log:        logger,
+		dial:       dial,
+		ctx:        ctx,
+		cancel:     cancel,
+		closeDelay: nodeCloseGracePeriod,
+		nodes:      make(map[string]*pooledNode),
+	}
+}
+
+// refresh replace the nodes of pool, connections of the nodes removed or changed are closed
+func (p *nodeClientPool) refresh(nodeList []*types.Node) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	nodes := make(map[string]*pooledNode, len(nodeList))
+	for _, node := range nodeList {
+		if pn, ok := p.nodes[node.Name]; ok && pn.node.URL == node.URL && pn.node.Token == node.Token {
+			pn.node = node
+			nodes[node.Name] = pn
+			continue
+		}
+		nodes[node.Name] = &pooledNode{node: node}
+	}
+	for name, pn := range p.nodes {
+		if nodes[name] != pn {
+			p.closeLater(pn)
+		}
+	}
+	p.nodes = nodes
+}
+
+type dialResult struct {
+	client *NodeClient
+	closer jsonrpc.ClientCloser
+	err    error
+}
+
+// clients return the connected nodes, and try to connect the others whose backoff expired,
+// nodes are dialed without holding the lock, so pushing to other nodes is not blocked by a slow node
+func (p *nodeClientPool) clients(now time.Time) []nodeClient {
+	p.lk.Lock()
+	toDial := make(map[string]*pooledNode)
+	for name, pn := range p.nodes {
+		if pn.client == nil && !pn.dialing && !now.Before(pn.nextRetry) {
+			pn.dialing = true
+			toDial[name] = pn
+		}
+	}
+	p.lk.Unlock()
+
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]dialResult, len(toDial))
+	for name, pn := range toDial {
+		wg.Add(1)
+		go func(name string, node *types.Node) {
+			defer wg.Done()
+			client, closer, err := p.dialTimeout(&config.NodeConfig{Token: node.Token, Url: node.URL})
+			lk.Lock()
+			results[name] = dialResult{client: client, closer: closer, err: err}
+			lk.Unlock()
+		}(name, pn.node)
+	}
+	wg.Wait()
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	for name, pn := range toDial {
+		res := results[name]
+		pn.dialing = false
+		if p.nodes[name] != pn {
+			// removed or changed while dialing
+			if res.err == nil {
+				res.closer()
+			}
+			continue
+		}
+		if res.err != nil {
+			pn.backoff(now)
+			pn.lastErr = res.err.Error()
+			p.log.Warnf("connect node(%s) %v, retry after %v", name, res.err, pn.nextRetry.Sub(now))
+			continue
+		}
+		pn.client, pn.closer, pn.failures, pn.lastErr = res.client, res.closer, 0, ""
+	}
+
+	nc := make([]nodeClient, 0, len(p.nodes))
+	for name, pn := range p.nodes {
+		if pn.client != nil {
+			nc = append(nc, nodeClient{name: name, node: pn.node, cli: pn.client})
+		}
+	}
+	return nc
+}
+
+// dialTimeout wait the connection at most nodeDialTimeout, the context of dial can't be bounded
+// as the connection is closed once the context done
+func (p *nodeClientPool) dialTimeout(cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error) {
+	resCh := make(chan dialResult, 1)
+	go func() {
+		client, closer, err := p.dial(p.ctx, cfg)
+		resCh <- dialResult{client: client, closer: closer, err: err}
+	}()
+
+	timer := time.NewTimer(nodeDialTimeout)
+	defer timer.Stop()
+	select {
+	case res := <-resCh:
+		return res.client, res.closer, res.err
+	case <-timer.C:
+		// close the connection established too late
+		go func() {
+			if res := <-resCh; res.err == nil {
+				res.closer()
+			}
+		}()
+		return nil, nil, xerrors.Errorf("connect timeout after %v", nodeDialTimeout)
+	}
+}
+
+// reset close the connection of node which is broken, it will be reconnected after backoff
+func (p *nodeClientPool) reset(name string, err error, now time.Time) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	if pn, ok := p.nodes[name]; ok && pn.client != nil {
+		p.closeLater(pn)
+		pn.backoff(now)
+		pn.lastErr = err.Error()
+	}
+}
+
+// lastError return the latest error of node, empty if the node is connected
+func (p *nodeClientPool) lastError(name string) string {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	if pn, ok := p.nodes[name]; ok {
+		if pn.lastErr == "" && pn.client == nil {
+			return "not connected"
+		}
+		return pn.lastErr
+	}
+	return "not connected"
+}
+
+func (p *nodeClientPool) close() {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	for _, pn := range p.nodes {
+		pn.close()
+	}
+	p.cancel()
+}
+
+// closeLater detach the connection from node and close it after the delay, so that calls already sent can finish
+func (p *nodeClientPool) closeLater(pn *pooledNode) {
+	closer := pn.closer
+	pn.client, pn.closer = nil, nil
+	if closer == nil {
+		return
+	}
+	if p.closeDelay <= 0 {
+		closer()
+		return
+	}
+	time.AfterFunc(p.closeDelay, closer)
+}
+
+// nodeUnavailable return true if the call failed on the client side or timeout, rather than rejected by node
+func nodeUnavailable(err error) bool {
+	var clientErr *jsonrpc.ErrClient
+	return xerrors.As(err, &clientErr) || xerrors.Is(err, context.DeadlineExceeded)
+}
+
+func (pn *pooledNode) backoff(now time.Time) {
+	backoff := nodeReconnectMinBackoff << uint(pn.failures)
+	if backoff > nodeReconnectMaxBackoff || backoff <= 0 {
+		backoff = nodeReconnectMaxBackoff
+	}
+	pn.failures++
+	pn.nextRetry = now.Add(backoff)
+}
+
+func (pn *pooledNode) close() {
+	if pn.closer != nil {
+		pn.closer()
+	}
+	pn.client, pn.closer = nil, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestNodeClientPool(t *testing.T) {
+	down := map[string]bool{}
+	dials := map[string]int{}
+	closed := map[string]int{}
+	// nodes are dialed concurrently
+	var lk sync.Mutex
+	dial := func(ctx context.Context, cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error) {
+		lk.Lock()
+		defer lk.Unlock()
+		dials[cfg.Url]++
+		if down[cfg.Url] {
+			return nil, nil, xerrors.New("connection refused")
+		}
+		return &NodeClient{}, func() { closed[cfg.Url]++ }, nil
+	}
+	names := func(nc []nodeClient) []string {
+		var res []string
+		for _, n := range nc {
+			res = append(res, n.name)
+		}
+		return res
+	}
+
+	pool := newNodeClientPool(log.New(), dial)
+	pool.closeDelay = 0
+	pool.refresh([]*types.Node{{Name: "a", URL: "url-a"}, {Name: "b", URL: "url-b"}})
+	down["url-b"] = true
+
+	now := time.Now()
+	assert.ElementsMatch(t, []string{"a"}, names(pool.clients(now)))
+	// connection is kept between rounds
+	assert.ElementsMatch(t, []string{"a"}, names(pool.clients(now)))
+	assert.Equal(t, 1, dials["url-a"])
+
+	// reconnect after backoff
+	assert.Equal(t, 1, dials["url-b"])
+	pool.clients(now.Add(nodeReconnectMinBackoff - time.Second))
+	assert.Equal(t, 1, dials["url-b"])
+	pool.clients(now.Add(nodeReconnectMinBackoff))
+	assert.Equal(t, 2, dials["url-b"])
+	// backoff doubles
+	pool.clients(now.Add(nodeReconnectMinBackoff * 2))
+	assert.Equal(t, 2, dials["url-b"])
+	down["url-b"] = false
+	assert.ElementsMatch(t, []string{"a", "b"}, names(pool.clients(now.Add(nodeReconnectMinBackoff*3))))
+
+	// reset broken connection
+	pool.reset("a", xerrors.New("broken pipe"), now)
+	assert.Equal(t, 1, closed["url-a"])
+	assert.Equal(t, "broken pipe", pool.lastError("a"))
+	assert.Equal(t, "", pool.lastError("b"))
+	assert.ElementsMatch(t, []string{"b"}, names(pool.clients(now)))
+
+	// nodes removed or changed are closed
+	pool.refresh([]*types.Node{{Name: "b", URL: "url-b2"}, {Name: "c", URL: "url-c"}})
+	assert.Equal(t, 1, closed["url-b"])
+	assert.ElementsMatch(t, []string{"b", "c"}, names(pool.clients(now)))
+	assert.Equal(t, 1, dials["url-b2"])
+
+	pool.close()
+	assert.Equal(t, 1, closed["url-b2"])
+	assert.Equal(t, 1, closed["url-c"])
+}
+
+func TestNodeClientPoolDial(t *testing.T) {
+	dialing := make(chan struct{})
+	unblock := make(chan struct{})
+	closed := make(chan string, 2)
+	dial := func(ctx context.Context, cfg *config.NodeConfig) (*NodeClient, jsonrpc.ClientCloser, error) {
+		if cfg.Url == "url-slow" {
+			close(dialing)
+			<-unblock
+		}
+		return &NodeClient{}, func() { closed <- cfg.Url }, nil
+	}
+
+	pool := newNodeClientPool(log.New(), dial)
+	pool.refresh([]*types.Node{{Name: "slow", URL: "url-slow"}})
+	done := make(chan []nodeClient)
+	go func() {
+		done <- pool.clients(time.Now())
+	}()
+
+	// the lock is not held while dialing, the node is removed before connected
+	<-dialing
+	pool.refresh([]*types.Node{{Name: "fast", URL: "url-fast"}})
+	assert.Len(t, pool.clients(time.Now()), 1)
+	close(unblock)
+	assert.Len(t, <-done, 1)
+	assert.Equal(t, "url-slow", <-closed)
+
+	// connection reset is closed after the grace period
+	pool.reset("fast", xerrors.New("broken pipe"), time.Now())
+	select {
+	case url := <-closed:
+		t.Fatalf("expect %s not closed at once", url)
+	default:
+	}
+	pool.close()
+}
+
+func TestNodeUnavailable(t *testing.T) {
+	assert.True(t, nodeUnavailable(&jsonrpc.ErrClient{}))
+	assert.True(t, nodeUnavailable(xerrors.Errorf("push: %w", context.DeadlineExceeded)))
+	assert.False(t, nodeUnavailable(xerrors.New("gas fee cap too low")))
+}
+
+func TestFilterNodeByRole(t *testing.T) {
+	nodeList := []*types.Node{
+		{Name: "full", Type: types.FullNode},
+		{Name: "light", Type: types.LightNode},
+		{Name: "untyped"},
+	}
+	names := func(nodeList []*types.Node) []string {
+		var res []string
+		for _, node := range nodeList {
+			res = append(res, node.Name)
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"full"}, names(filterNodeByRole(nodeList, types.ChainRole)))
+	assert.Equal(t, []string{"full", "light", "untyped"}, names(filterNodeByRole(nodeList, types.BroadcastRole)))
+}
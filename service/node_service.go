@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 
+	"golang.org/x/xerrors"
+
 	"github.com/filecoin-project/venus-messager/config"
 	"github.com/filecoin-project/venus-messager/log"
 	"github.com/filecoin-project/venus-messager/models/repo"
@@ -12,13 +14,47 @@ import (
 type NodeService struct {
 	repo repo.Repo
 	log  *log.Logger
+
+	health *nodeHealthMonitor
+	pool   *nodeClientPool
 }
 
 func NewNodeService(repo repo.Repo, logger *log.Logger) *NodeService {
-	return &NodeService{repo: repo, log: logger}
+	ns := &NodeService{repo: repo, log: logger, health: newNodeHealthMonitor(), pool: newNodeClientPool(logger, NewNodeClient)}
+	if err := ns.refreshPool(); err != nil {
+		logger.Errorf("refresh node pool failed %v", err)
+	}
+	return ns
+}
+
+// refreshPool sync the connection pool with the nodes in database
+func (ns *NodeService) refreshPool() error {
+	nodeList, err := ns.repo.NodeRepo().ListNode()
+	if err != nil {
+		return err
+	}
+	ns.pool.refresh(nodeList)
+	return nil
+}
+
+// filterNodeByRole return the nodes can serve the role, see types.NodeType.HasRole
+func filterNodeByRole(nodeList []*types.Node, role types.NodeRole) []*types.Node {
+	res := make([]*types.Node, 0, len(nodeList))
+	for _, node := range nodeList {
+		if node.Type.HasRole(role) {
+			res = append(res, node)
+		}
+	}
+	return res
 }
 
 func (ns *NodeService) SaveNode(ctx context.Context, node *types.Node) (struct{}, error) {
+	if node.Type == 0 {
+		node.Type = types.LightNode
+	}
+	if node.Type != types.FullNode && node.Type != types.LightNode {
+		return struct{}{}, xerrors.Errorf("unknown node type %d", node.Type)
+	}
 	// try connect node
 	_, close, err := NewNodeClient(context.TODO(), &config.NodeConfig{Token: node.Token, Url: node.URL})
 	if err != nil {
@@ -29,26 +65,46 @@ func (ns *NodeService) SaveNode(ctx context.Context, node *types.Node) (struct{}
 		return struct{}{}, err
 	}
 	ns.log.Infof("add node %s", node.Name)
+	if err := ns.refreshPool(); err != nil {
+		ns.log.Errorf("refresh node pool failed %v", err)
+	}
 
 	return struct{}{}, nil
 }
 
 func (ns *NodeService) GetNode(ctx context.Context, name string) (*types.Node, error) {
-	return ns.repo.NodeRepo().GetNode(name)
+	node, err := ns.repo.NodeRepo().GetNode(name)
+	if err != nil {
+		return nil, err
+	}
+	node.Health = ns.health.get(node.Name)
+	return node, nil
 }
 
 func (ns *NodeService) HasNode(ctx context.Context, name string) (bool, error) {
 	return ns.repo.NodeRepo().HasNode(name)
 }
 
+// ListNode return nodes with the health of the latest check
 func (ns *NodeService) ListNode(ctx context.Context) ([]*types.Node, error) {
-	return ns.repo.NodeRepo().ListNode()
+	nodeList, err := ns.repo.NodeRepo().ListNode()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodeList {
+		node.Health = ns.health.get(node.Name)
+	}
+	return nodeList, nil
 }
 
 func (ns *NodeService) DeleteNode(ctx context.Context, name string) (struct{}, error) {
 	if err := ns.repo.NodeRepo().DelNode(name); err != nil {
 		return struct{}{}, err
 	}
+	ns.health.remove(name)
+	if err := ns.refreshPool(); err != nil {
+		ns.log.Errorf("refresh node pool failed %v", err)
+	}
 	ns.log.Infof("delete node %s", name)
 
 	return struct{}{}, nil
@@ -52,6 +52,7 @@ func (ms *MessageService) Send(ctx context.Context, params types.SendParams) (st
 			Method: params.Method,
 			Params: decParams,
 		},
+		Meta:       &types.MsgMeta{Priority: params.Priority},
 		State:      types.UnFillMsg,
 		WalletName: params.Account,
 		FromUser:   params.Account,
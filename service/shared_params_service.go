@@ -28,6 +28,9 @@ var defParams = &types.SharedParams{
 	SelMsgNum:          20,
 	ScanInterval:       10,
 	MaxEstFailNumOfMsg: 5,
+	FeeStrategy:        NodeEstimateStrategy,
+	FixedPremium:       big.NewInt(0),
+	BaseFeeThreshold:   big.NewInt(0),
 }
 
 type SharedParamsService struct {
@@ -75,6 +78,9 @@ func (sps *SharedParamsService) GetSharedParams(ctx context.Context) (*types.Sha
 }
 
 func (sps *SharedParamsService) SetSharedParams(ctx context.Context, params *types.SharedParams) (struct{}, error) {
+	if err := checkSharedFeeStrategy(params); err != nil {
+		return struct{}{}, err
+	}
 	id, err := sps.repo.SharedParamsRepo().SetSharedParams(ctx, params)
 	if err != nil {
 		return struct{}{}, err
@@ -110,7 +116,15 @@ func (sps *SharedParamsService) SetParams(sharedParams *types.SharedParams) {
 			sps.params.ScanIntervalChan <- time.Duration(sharedParams.ScanInterval) * time.Second
 		}
 	}
+	sps.params.MaxSignPerEpoch = sharedParams.MaxSignPerEpoch
 	sps.params.MaxEstFailNumOfMsg = sharedParams.MaxEstFailNumOfMsg
+	sps.params.FeeStrategy = sharedParams.FeeStrategy
+	sps.params.FixedPremium = sharedParams.FixedPremium
+	sps.params.BaseFeeMultiplier = sharedParams.BaseFeeMultiplier
+	sps.params.PremiumPercentile = sharedParams.PremiumPercentile
+	sps.params.MethodFeeStrategy = sharedParams.MethodFeeStrategy
+	sps.params.BaseFeeThreshold = sharedParams.BaseFeeThreshold
+	sps.params.FairShare = sharedParams.FairShare
 	sps.log.Infof("new params %v", sharedParams)
 }
 
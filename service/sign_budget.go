@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+type addrDemand struct {
+	addr   address.Address
+	weight int64
+	demand uint64
+}
+
+// signBudget return the max messages each address can sign in this selection, when the max sign per epoch of shared params
+// is set, the budget is shared by the addresses which have messages to sign according to their weights. the demand of
+// address is limited by the messages pending on chain like selectAddrMessage, so no budget is wasted on it. the nonces
+// got from chain for the demands are returned too, selectAddrMessage need not get them again
+func (messageSelector *MessageSelector) signBudget(ctx context.Context,
+	appliedNonce *types.NonceMap,
+	ts *venusTypes.TipSet,
+	addrList []*types.Address,
+	addrSelMsgNum map[address.Address]uint64) (map[address.Address]uint64, map[address.Address]*chainNonce, error) {
+	var maxSign uint64
+	if messageSelector.sps.GetParams().SharedParams != nil {
+		maxSign = messageSelector.sps.GetParams().MaxSignPerEpoch
+	}
+	if maxSign == 0 {
+		return addrSelMsgNum, nil, nil
+	}
+
+	demands := make([]addrDemand, 0, len(addrList))
+	nonces := make(map[address.Address]*chainNonce, len(addrList))
+	var demandErr error
+	var lk sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, selectConcurrency)
+	for _, addr := range addrList {
+		if addr.State != types.Alive && addr.State != types.Forbiden {
+			continue
+		}
+		wg.Add(1)
+		go func(addr *types.Address) {
+			sem <- struct{}{}
+			defer func() {
+				wg.Done()
+				<-sem
+			}()
+
+			demand, nonce, err := messageSelector.signDemand(ctx, appliedNonce, ts, addr, addrSelMsgNum[addr.Addr])
+			lk.Lock()
+			defer lk.Unlock()
+			if err != nil {
+				demandErr = err
+				return
+			}
+			demands = append(demands, addrDemand{addr: addr.Addr, weight: addr.Weight, demand: demand})
+			if nonce != nil {
+				nonces[addr.Addr] = nonce
+			}
+		}(addr)
+	}
+	wg.Wait()
+	if demandErr != nil {
+		return nil, nil, demandErr
+	}
+
+	budget := allocateSignBudget(maxSign, demands)
+	messageSelector.log.Infof("max sign per epoch %d, budget of addresses %v", maxSign, budget)
+	return budget, nonces, nil
+}
+
+// signDemand return the messages addr want to sign, and the nonce got from chain if any
+func (messageSelector *MessageSelector) signDemand(ctx context.Context,
+	appliedNonce *types.NonceMap,
+	ts *venusTypes.TipSet,
+	addr *types.Address,
+	selMsgNum uint64) (uint64, *chainNonce, error) {
+	count, err := messageSelector.repo.MessageRepo().CountUnChainMessageByAddress(addr.Addr)
+	if err != nil {
+		return 0, nil, xerrors.Errorf("count %s unpackage message error %v", addr.Addr, err)
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+	nonce, err := messageSelector.nonceInLatestTs(ctx, appliedNonce, addr.Addr, ts)
+	if err != nil {
+		// selectAddrMessage fails too, no budget for it
+		messageSelector.log.Warnf("get nonce of %s failed %v", addr.Addr, err)
+		return 0, nil, nil
+	}
+	// nonce in database smaller than on chain is updated to the chain by selectAddrMessage
+	assignedNonce := addr.Nonce
+	if nonce.nonceInLatestTs > assignedNonce {
+		assignedNonce = nonce.nonceInLatestTs
+	}
+	var demand uint64
+	if nonceGap := assignedNonce - nonce.nonceInLatestTs; nonceGap < selMsgNum {
+		demand = selMsgNum - nonceGap
+	}
+	if uint64(count) < demand {
+		demand = uint64(count)
+	}
+	return demand, nonce, nil
+}
+
+// allocateSignBudget share total among demands by weight, address not weighted is treated as weight 1,
+// the part not needed by an address goes to the others
+func allocateSignBudget(total uint64, demands []addrDemand) map[address.Address]uint64 {
+	sort.Slice(demands, func(i, j int) bool {
+		if demands[i].weight != demands[j].weight {
+			return demands[i].weight > demands[j].weight
+		}
+		return demands[i].addr.String() < demands[j].addr.String()
+	})
+	weight := func(d addrDemand) uint64 {
+		if d.weight <= 0 {
+			return 1
+		}
+		return uint64(d.weight)
+	}
+
+	budget := make(map[address.Address]uint64, len(demands))
+	remain := total
+	for remain > 0 {
+		var active []addrDemand
+		var sumWeight uint64
+		for _, d := range demands {
+			if budget[d.addr] < d.demand {
+				active = append(active, d)
+				sumWeight += weight(d)
+			}
+		}
+		if len(active) == 0 {
+			break
+		}
+
+		var given uint64
+		for _, d := range active {
+			share := remain * weight(d) / sumWeight
+			if need := d.demand - budget[d.addr]; share > need {
+				share = need
+			}
+			budget[d.addr] += share
+			given += share
+		}
+		// the share of all is rounded down to zero, give the rest one by one in order of weight
+		if given == 0 {
+			for _, d := range active {
+				if remain == 0 {
+					break
+				}
+				budget[d.addr]++
+				remain--
+			}
+			continue
+		}
+		remain -= given
+	}
+
+	for _, d := range demands {
+		if _, ok := budget[d.addr]; !ok {
+			budget[d.addr] = 0
+		}
+	}
+	return budget
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestAllocateSignBudget(t *testing.T) {
+	addr1, _ := address.NewIDAddress(1001)
+	addr2, _ := address.NewIDAddress(1002)
+	addr3, _ := address.NewIDAddress(1003)
+
+	t.Run("share by weight", func(t *testing.T) {
+		budget := allocateSignBudget(10, []addrDemand{
+			{addr: addr1, weight: 1, demand: 20},
+			{addr: addr2, weight: 4, demand: 20},
+		})
+		assert.Equal(t, uint64(2), budget[addr1])
+		assert.Equal(t, uint64(8), budget[addr2])
+	})
+
+	t.Run("unused share goes to others", func(t *testing.T) {
+		budget := allocateSignBudget(10, []addrDemand{
+			{addr: addr1, weight: 1, demand: 20},
+			{addr: addr2, weight: 4, demand: 2},
+			{addr: addr3, weight: 5, demand: 0},
+		})
+		assert.Equal(t, uint64(8), budget[addr1])
+		assert.Equal(t, uint64(2), budget[addr2])
+		assert.Equal(t, uint64(0), budget[addr3])
+	})
+
+	t.Run("zero weight as one", func(t *testing.T) {
+		budget := allocateSignBudget(3, []addrDemand{
+			{addr: addr1, demand: 20},
+			{addr: addr2, demand: 20},
+		})
+		assert.Equal(t, uint64(2), budget[addr1])
+		assert.Equal(t, uint64(1), budget[addr2])
+	})
+
+	t.Run("budget more than demand", func(t *testing.T) {
+		budget := allocateSignBudget(100, []addrDemand{
+			{addr: addr1, weight: 1, demand: 5},
+			{addr: addr2, weight: 2, demand: 6},
+		})
+		assert.Equal(t, uint64(5), budget[addr1])
+		assert.Equal(t, uint64(6), budget[addr2])
+	})
+}
+
+func TestSignBudgetNonceGap(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "sign_budget.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("sign_budget.db"))
+		assert.NoError(t, os.Remove("sign_budget.db-shm"))
+		assert.NoError(t, os.Remove("sign_budget.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	addr1, _ := address.NewIDAddress(1001)
+	addr2, _ := address.NewIDAddress(1002)
+	addr3, _ := address.NewIDAddress(1003)
+	// addr1 has 3 messages not on chain yet, nonce of addr3 in database is behind the chain
+	addrList := []*types.Address{
+		{Addr: addr1, Nonce: 5, State: types.Alive},
+		{Addr: addr2, Nonce: 2, State: types.Alive},
+		{Addr: addr3, Nonce: 0, State: types.Alive},
+	}
+	nonceOnChain := map[address.Address]uint64{addr1: 2, addr2: 2, addr3: 7}
+	for _, addr := range addrList {
+		for _, msg := range models.NewMessages(10) {
+			msg.From = addr.Addr
+			assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+		}
+	}
+
+	messageSelector := &MessageSelector{
+		repo: db,
+		log:  log.New(),
+		sps:  &SharedParamsService{params: &Params{SharedParams: &types.SharedParams{SelMsgNum: 5, MaxSignPerEpoch: 100}}},
+		nodeClient: &NodeClient{
+			StateGetActor: func(ctx context.Context, addr address.Address, tsk venusTypes.TipSetKey) (*venusTypes.Actor, error) {
+				return &venusTypes.Actor{Nonce: nonceOnChain[addr]}, nil
+			},
+		},
+	}
+	selMsgNum := messageSelector.addrSelectMsgNum(addrList)
+	budget, nonces, err := messageSelector.signBudget(context.Background(), types.NewNonceMap(), newMockTipSet(t, 10), addrList, selMsgNum)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), budget[addr1])
+	assert.Equal(t, uint64(5), budget[addr2])
+	assert.Equal(t, uint64(5), budget[addr3])
+	// nonces are reused by selecting
+	for _, addr := range addrList {
+		assert.Equal(t, nonceOnChain[addr.Addr], nonces[addr.Addr].nonceInLatestTs)
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/specactors/builtin"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+const signPolicyPrefix = "sign policy: "
+
+// the window of max daily value
+const dailyValueWindow = 24 * time.Hour
+
+type SignPolicyService struct {
+	repo       repo.Repo
+	log        *log.Logger
+	nodeClient *NodeClient
+}
+
+func NewSignPolicyService(repo repo.Repo, logger *log.Logger, nodeClient *NodeClient) *SignPolicyService {
+	return &SignPolicyService{repo: repo, log: logger, nodeClient: nodeClient}
+}
+
+// SaveSignPolicy add a policy, or update the policy with the same id
+func (policyService *SignPolicyService) SaveSignPolicy(ctx context.Context, policy *types.SignPolicy) (types.UUID, error) {
+	if policy.ID == (types.UUID{}) {
+		policy.ID = types.NewUUID()
+		policy.CreatedAt = time.Now()
+	} else {
+		exist, err := policyService.repo.SignPolicyRepo().GetSignPolicy(ctx, policy.ID)
+		if err != nil {
+			return types.UUID{}, xerrors.Errorf("get sign policy %s %w", policy.ID, err)
+		}
+		policy.CreatedAt = exist.CreatedAt
+	}
+	policy.UpdatedAt = time.Now()
+	if err := policyService.repo.SignPolicyRepo().SaveSignPolicy(ctx, policy); err != nil {
+		return types.UUID{}, err
+	}
+	policyService.log.Infof("save sign policy %s for address %s account %s", policy.ID, policy.Addr, policy.Account)
+
+	return policy.ID, nil
+}
+
+func (policyService *SignPolicyService) ListSignPolicy(ctx context.Context) ([]*types.SignPolicy, error) {
+	return policyService.repo.SignPolicyRepo().ListSignPolicy(ctx)
+}
+
+func (policyService *SignPolicyService) DeleteSignPolicy(ctx context.Context, id types.UUID) (struct{}, error) {
+	if _, err := policyService.repo.SignPolicyRepo().GetSignPolicy(ctx, id); err != nil {
+		return struct{}{}, xerrors.Errorf("get sign policy %s %w", id, err)
+	}
+	if err := policyService.repo.SignPolicyRepo().DelSignPolicy(ctx, id); err != nil {
+		return struct{}{}, err
+	}
+	policyService.log.Infof("delete sign policy %s", id)
+
+	return struct{}{}, nil
+}
+
+// signPolicyChecker check messages against policies before signing, it is used by one selection round,
+// and reserve the value of messages passed for max daily value until they fail to be signed
+type signPolicyChecker struct {
+	ctx           context.Context
+	policyService *SignPolicyService
+	policies      []*types.SignPolicy
+
+	lk sync.Mutex
+	// policy id -> message id -> value of messages signed in the window
+	dailyValues map[types.UUID]map[string]big.Int
+	// message id -> policy id -> value counted before the message reserved, nil if not counted
+	reserved   map[string]map[types.UUID]*big.Int
+	actorNames map[address.Address]string
+}
+
+func (policyService *SignPolicyService) newChecker(ctx context.Context) (*signPolicyChecker, error) {
+	policies, err := policyService.repo.SignPolicyRepo().ListSignPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &signPolicyChecker{
+		ctx:           ctx,
+		policyService: policyService,
+		policies:      policies,
+		dailyValues:   make(map[types.UUID]map[string]big.Int),
+		reserved:      make(map[string]map[types.UUID]*big.Int),
+		actorNames:    make(map[address.Address]string),
+	}, nil
+}
+
+// check return the reason if msg violates any policy, error means failed to check. the value of msg is reserved
+// for max daily value if it passed, call release if it is not signed at last
+func (checker *signPolicyChecker) check(msg *types.Message) (string, error) {
+	// the actor is got from node, not to block other messages checked concurrently
+	var actorName string
+	for _, policy := range checker.policies {
+		if len(policy.AllowedMethods) > 0 && policy.Match(msg.From, msg.FromUser) {
+			actorName = checker.actorName(msg.To)
+			break
+		}
+	}
+
+	checker.lk.Lock()
+	defer checker.lk.Unlock()
+
+	var dailyPolicies []*types.SignPolicy
+	for _, policy := range checker.policies {
+		if !policy.Match(msg.From, msg.FromUser) {
+			continue
+		}
+		dailyValue := big.Zero()
+		if !policy.MaxDailyValue.NilOrZero() {
+			values, err := checker.loadDailyValues(policy)
+			if err != nil {
+				return "", err
+			}
+			for id, val := range values {
+				if id != msg.ID {
+					dailyValue = big.Add(dailyValue, val)
+				}
+			}
+			dailyPolicies = append(dailyPolicies, policy)
+		}
+		if reason := checkSignPolicy(policy, &msg.UnsignedMessage, actorName, dailyValue); len(reason) > 0 {
+			return fmt.Sprintf("%s%s violates policy %s", signPolicyPrefix, reason, policy.ID), nil
+		}
+	}
+
+	if len(dailyPolicies) == 0 {
+		return "", nil
+	}
+	prevValues, reserved := checker.reserved[msg.ID]
+	if !reserved {
+		prevValues = make(map[types.UUID]*big.Int, len(dailyPolicies))
+		for _, policy := range dailyPolicies {
+			if val, ok := checker.dailyValues[policy.ID][msg.ID]; ok {
+				prevValues[policy.ID] = &val
+			} else {
+				prevValues[policy.ID] = nil
+			}
+		}
+		checker.reserved[msg.ID] = prevValues
+	}
+	for _, policy := range dailyPolicies {
+		checker.dailyValues[policy.ID][msg.ID] = msg.Value
+	}
+	return "", nil
+}
+
+// release give back the value reserved by msg when checked, msg is not signed
+func (checker *signPolicyChecker) release(msg *types.Message) {
+	checker.lk.Lock()
+	defer checker.lk.Unlock()
+
+	for policyID, prevValue := range checker.reserved[msg.ID] {
+		if prevValue == nil {
+			delete(checker.dailyValues[policyID], msg.ID)
+		} else {
+			checker.dailyValues[policyID][msg.ID] = *prevValue
+		}
+	}
+	delete(checker.reserved, msg.ID)
+}
+
+func (checker *signPolicyChecker) loadDailyValues(policy *types.SignPolicy) (map[string]big.Int, error) {
+	if values, ok := checker.dailyValues[policy.ID]; ok {
+		return values, nil
+	}
+	msgs, err := checker.policyService.repo.MessageRepo().ListSignedMessageSince(policy.Addr, policy.Account, time.Now().Add(-dailyValueWindow))
+	if err != nil {
+		return nil, xerrors.Errorf("list signed message of policy %s %v", policy.ID, err)
+	}
+	values := make(map[string]big.Int, len(msgs))
+	for _, msg := range msgs {
+		values[msg.ID] = msg.Value
+	}
+	checker.dailyValues[policy.ID] = values
+	return values, nil
+}
+
+// actorName return the name of actor without version, eg. storageminer, empty if actor not found
+func (checker *signPolicyChecker) actorName(addr address.Address) string {
+	checker.lk.Lock()
+	name, ok := checker.actorNames[addr]
+	checker.lk.Unlock()
+	if ok {
+		return name
+	}
+
+	actor, err := checker.policyService.nodeClient.StateGetActor(checker.ctx, addr, venusTypes.EmptyTSK)
+	if err == nil {
+		name = builtin.ActorNameByCode(actor.Code)
+		name = name[strings.LastIndex(name, "/")+1:]
+	} else if addr.Protocol() == address.SECP256K1 || addr.Protocol() == address.BLS {
+		// actor of key address will be created by the first message sent to it
+		name = "account"
+	} else {
+		checker.policyService.log.Warnf("get actor %s failed %v", addr, err)
+	}
+	checker.lk.Lock()
+	checker.actorNames[addr] = name
+	checker.lk.Unlock()
+	return name
+}
+
+// checkSignPolicy return the reason if msg violates the policy, dailyValue is the value signed in the window except msg
+func checkSignPolicy(policy *types.SignPolicy, msg *venusTypes.UnsignedMessage, actorName string, dailyValue big.Int) string {
+	if len(policy.AllowedTo) > 0 {
+		allowed := false
+		for _, to := range policy.AllowedTo {
+			if to == msg.To {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("receiver %s not allowed", msg.To)
+		}
+	}
+
+	if len(policy.AllowedMethods) > 0 {
+		methods, ok := policy.AllowedMethods[actorName]
+		if !ok {
+			return fmt.Sprintf("actor %s of receiver %s not allowed", actorName, msg.To)
+		}
+		if len(methods) > 0 {
+			methodName := fmt.Sprintf("%d", msg.Method)
+			for code, actorMethods := range types.MethodsMap {
+				if !strings.HasSuffix(builtin.ActorNameByCode(code), "/"+actorName) {
+					continue
+				}
+				if meta, found := actorMethods[msg.Method]; found {
+					methodName = meta.Name
+					break
+				}
+			}
+			allowed := false
+			for _, m := range methods {
+				if m == methodName {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return fmt.Sprintf("method %s of actor %s not allowed", methodName, actorName)
+			}
+		}
+	}
+
+	if !policy.MaxValue.NilOrZero() && msg.Value.GreaterThan(policy.MaxValue) {
+		return fmt.Sprintf("value %s exceeds max value %s", msg.Value, policy.MaxValue)
+	}
+
+	if !policy.MaxDailyValue.NilOrZero() {
+		total := big.Add(dailyValue, msg.Value)
+		if total.GreaterThan(policy.MaxDailyValue) {
+			return fmt.Sprintf("daily value %s exceeds max daily value %s", total, policy.MaxDailyValue)
+		}
+	}
+
+	if !policy.MaxFee.NilOrZero() {
+		fee := big.Mul(msg.GasFeeCap, big.NewInt(msg.GasLimit))
+		if fee.GreaterThan(policy.MaxFee) {
+			return fmt.Sprintf("fee %s exceeds max fee %s", fee, policy.MaxFee)
+		}
+	}
+
+	return ""
+}
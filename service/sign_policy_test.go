@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/specs-actors/v5/actors/builtin"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestCheckSignPolicy(t *testing.T) {
+	from, _ := address.NewIDAddress(1000)
+	to, _ := address.NewIDAddress(1001)
+	other, _ := address.NewIDAddress(1002)
+	newMsg := func() *venusTypes.UnsignedMessage {
+		return &venusTypes.UnsignedMessage{
+			From:      from,
+			To:        to,
+			Value:     big.NewInt(100),
+			Method:    builtin.MethodsMiner.PreCommitSector,
+			GasLimit:  10,
+			GasFeeCap: big.NewInt(10),
+		}
+	}
+
+	t.Run("match", func(t *testing.T) {
+		policy := &types.SignPolicy{Addr: from}
+		assert.True(t, policy.Match(from, "user"))
+		assert.False(t, policy.Match(other, "user"))
+
+		policy = &types.SignPolicy{Account: "user"}
+		assert.True(t, policy.Match(other, "user"))
+		assert.False(t, policy.Match(from, "other"))
+	})
+
+	t.Run("allowed to", func(t *testing.T) {
+		assert.Empty(t, checkSignPolicy(&types.SignPolicy{AllowedTo: []address.Address{to}}, newMsg(), "", big.Zero()))
+		assert.NotEmpty(t, checkSignPolicy(&types.SignPolicy{AllowedTo: []address.Address{other}}, newMsg(), "", big.Zero()))
+	})
+
+	t.Run("allowed methods", func(t *testing.T) {
+		policy := &types.SignPolicy{AllowedMethods: map[string][]string{"storageminer": {"PreCommitSector"}}}
+		assert.Empty(t, checkSignPolicy(policy, newMsg(), "storageminer", big.Zero()))
+		assert.NotEmpty(t, checkSignPolicy(policy, newMsg(), "multisig", big.Zero()))
+
+		msg := newMsg()
+		msg.Method = builtin.MethodsMiner.WithdrawBalance
+		assert.NotEmpty(t, checkSignPolicy(policy, msg, "storageminer", big.Zero()))
+
+		policy = &types.SignPolicy{AllowedMethods: map[string][]string{"storageminer": nil}}
+		assert.Empty(t, checkSignPolicy(policy, msg, "storageminer", big.Zero()))
+	})
+
+	t.Run("value", func(t *testing.T) {
+		assert.Empty(t, checkSignPolicy(&types.SignPolicy{MaxValue: big.NewInt(100)}, newMsg(), "", big.Zero()))
+		assert.NotEmpty(t, checkSignPolicy(&types.SignPolicy{MaxValue: big.NewInt(99)}, newMsg(), "", big.Zero()))
+
+		policy := &types.SignPolicy{MaxDailyValue: big.NewInt(250)}
+		assert.Empty(t, checkSignPolicy(policy, newMsg(), "", big.NewInt(150)))
+		assert.NotEmpty(t, checkSignPolicy(policy, newMsg(), "", big.NewInt(151)))
+	})
+
+	t.Run("fee", func(t *testing.T) {
+		assert.Empty(t, checkSignPolicy(&types.SignPolicy{MaxFee: big.NewInt(100)}, newMsg(), "", big.Zero()))
+		assert.NotEmpty(t, checkSignPolicy(&types.SignPolicy{MaxFee: big.NewInt(99)}, newMsg(), "", big.Zero()))
+	})
+}
+
+func TestSignPolicyCheckerReserve(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "sign_policy_reserve.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("sign_policy_reserve.db"))
+		assert.NoError(t, os.Remove("sign_policy_reserve.db-shm"))
+		assert.NoError(t, os.Remove("sign_policy_reserve.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	ctx := context.Background()
+	policyService := NewSignPolicyService(db, log.New(), &NodeClient{})
+	_, err = policyService.SaveSignPolicy(ctx, &types.SignPolicy{Account: "user", MaxDailyValue: big.NewInt(10)})
+	assert.NoError(t, err)
+	checker, err := policyService.newChecker(ctx)
+	assert.NoError(t, err)
+
+	msgs := models.NewMessages(10)
+	for _, msg := range msgs {
+		msg.FromUser = "user"
+		msg.Value = big.NewInt(6)
+	}
+	// only one message passes when checked concurrently
+	passed := make(chan *types.Message, len(msgs))
+	var wg sync.WaitGroup
+	for _, msg := range msgs {
+		wg.Add(1)
+		go func(msg *types.Message) {
+			defer wg.Done()
+			reason, err := checker.check(msg)
+			assert.NoError(t, err)
+			if len(reason) == 0 {
+				passed <- msg
+			}
+		}(msg)
+	}
+	wg.Wait()
+	close(passed)
+	assert.Len(t, passed, 1)
+	signed := <-passed
+
+	// checked again before signed, the value is not counted twice
+	reason, err := checker.check(signed)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	// the value is given back if failed to sign
+	other := msgs[0]
+	if other == signed {
+		other = msgs[1]
+	}
+	reason, err = checker.check(other)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reason)
+	checker.release(signed)
+	reason, err = checker.check(other)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func TestUnblockMessage(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "unblock_message.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("unblock_message.db"))
+		assert.NoError(t, os.Remove("unblock_message.db-shm"))
+		assert.NoError(t, os.Remove("unblock_message.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	msgs := models.NewMessages(2)
+	msgs[0].State = types.BlockedByPolicyMsg
+	for _, msg := range msgs {
+		assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+	}
+
+	msgState, err := NewMessageState(db, log.New(), &config.MessageStateConfig{
+		BackTime:          60,
+		CleanupInterval:   3,
+		DefaultExpiration: 2,
+	})
+	assert.NoError(t, err)
+	head := newMockTipSet(t, 10)
+	ms := &MessageService{
+		repo:         db,
+		log:          log.New(),
+		messageState: msgState,
+		nodeClient: &NodeClient{
+			ChainHead: func(context.Context) (*venusTypes.TipSet, error) {
+				return head, nil
+			},
+		},
+	}
+	ctx := context.Background()
+
+	_, err = ms.UnblockMessage(ctx, msgs[0].ID)
+	assert.NoError(t, err)
+	msg, err := db.MessageRepo().GetMessageByUid(msgs[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.UnFillMsg, msg.State)
+	cached, ok := msgState.GetMessage(msgs[0].ID)
+	assert.True(t, ok)
+	assert.Equal(t, types.UnFillMsg, cached.State)
+
+	// not blocked
+	_, err = ms.UnblockMessage(ctx, msgs[1].ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	venusCrypto "github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/xerrors"
+)
+
+// blsDomain is the ciphersuite of bls signature used by filecoin
+var blsDomain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+
+func init() {
+	// venus/pkg/crypto/bls is built on filecoin-ffi which messager doesn't link, verify bls signature in go instead
+	venusCrypto.RegisterSignature(crypto.SigTypeBLS, blsVerifier{})
+}
+
+// verifySignature check sig is signed by addr, addr must be a key address
+func verifySignature(sig *crypto.Signature, addr address.Address, msg []byte) error {
+	return venusCrypto.Verify(sig, addr, msg)
+}
+
+// blsVerifier only verify bls signatures, messager never holds private keys
+type blsVerifier struct{}
+
+var errBLSVerifyOnly = xerrors.New("bls private key not supported by messager")
+
+func (blsVerifier) GenPrivate() ([]byte, error) {
+	return nil, errBLSVerifyOnly
+}
+
+func (blsVerifier) GenPrivateFromSeed(seed io.Reader) ([]byte, error) {
+	return nil, errBLSVerifyOnly
+}
+
+func (blsVerifier) ToPublic(pk []byte) ([]byte, error) {
+	return nil, errBLSVerifyOnly
+}
+
+func (blsVerifier) Sign(pk []byte, msg []byte) ([]byte, error) {
+	return nil, errBLSVerifyOnly
+}
+
+func (blsVerifier) VerifyAggregate(pubKeys, msgs [][]byte, signature []byte) bool {
+	return false
+}
+
+// Verify check e(pk, H(msg)) == e(g1, sig), the public key is the payload of bls address
+func (blsVerifier) Verify(sig []byte, addr address.Address, msg []byte) error {
+	if addr.Protocol() != address.BLS {
+		return xerrors.Errorf("bls signature not match address %s", addr)
+	}
+	g1 := bls12381.NewG1()
+	pubKey, err := g1.FromCompressed(addr.Payload())
+	if err != nil {
+		return xerrors.Errorf("decode public key failed %v", err)
+	}
+	if !g1.InCorrectSubgroup(pubKey) || g1.IsZero(pubKey) {
+		return xerrors.New("invalid public key")
+	}
+
+	g2 := bls12381.NewG2()
+	sigPoint, err := g2.FromCompressed(sig)
+	if err != nil {
+		return xerrors.Errorf("decode signature failed %v", err)
+	}
+	if !g2.InCorrectSubgroup(sigPoint) {
+		return xerrors.New("invalid signature")
+	}
+	hash, err := g2.HashToCurve(msg, blsDomain)
+	if err != nil {
+		return err
+	}
+
+	engine := bls12381.NewEngine()
+	engine.AddPairInv(g1.One(), sigPoint)
+	engine.AddPair(pubKey, hash)
+	if !engine.Check() {
+		return xerrors.New("bls signature verify failed")
+	}
+	return nil
+}
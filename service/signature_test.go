@@ -0,0 +1,52 @@
+package service
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	msg := []byte("hello filecoin")
+
+	// signed by filecoin-ffi
+	cases := []struct {
+		addr string
+		typ  crypto.SigType
+		sig  string
+	}{
+		{
+			addr: "t17rmoshisxovfjkrox2gpryaupmsalzy2tlaluiq",
+			typ:  crypto.SigTypeSecp256k1,
+			sig:  "4c49bacbd5a1e9734595d77a7ae8909ae35859e182344c0f1c081d8fdc6749302d50ae81d0d80c9c4be9cf3071bf7b0c465a19aea1023b21239b8bbdffd0ca8d01",
+		},
+		{
+			addr: "t3tho7tbdrw3xuy6ybz6p5tfc7nsqqa2ozhsrnmhwynrb3keeaeywe3felidbpgvy2wcxktmxcezlpz7pbu5pq",
+			typ:  crypto.SigTypeBLS,
+			sig:  "828e485cf906ae5deda33ec3e16b2a5ac761ab5a6109f481134a4dd40d7b5c3b03d187f614c7dd85d142ff50b902bdf50923f58b5bb741b4a522bf17f8efe5cf3f8f51715cd31a765eb5e5d76889102752d2cc2efa4287829cacb4de2be07cf7",
+		},
+	}
+	for _, c := range cases {
+		addr, err := address.NewFromString(c.addr)
+		assert.NoError(t, err)
+		data, err := hex.DecodeString(c.sig)
+		assert.NoError(t, err)
+		sig := &crypto.Signature{Type: c.typ, Data: data}
+
+		assert.NoError(t, verifySignature(sig, addr, msg))
+		assert.Error(t, verifySignature(sig, addr, []byte("hello venus")))
+
+		data[10] ^= 0xff
+		assert.Error(t, verifySignature(sig, addr, msg))
+	}
+
+	secpAddr, err := address.NewFromString(cases[0].addr)
+	assert.NoError(t, err)
+	blsSig, err := hex.DecodeString(cases[1].sig)
+	assert.NoError(t, err)
+	assert.Error(t, verifySignature(&crypto.Signature{Type: crypto.SigTypeBLS, Data: blsSig}, secpAddr, msg))
+	assert.Error(t, verifySignature(nil, secpAddr, msg))
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	"github.com/filecoin-project/venus-messager/models/repo"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// PushSignedMessage import a message signed out of messager, such as by a hardware wallet or offline,
+// the message is broadcast and saved as FillMsg, then tracked like the messages signed by messager
+func (ms *MessageService) PushSignedMessage(ctx context.Context, smsg *venusTypes.SignedMessage, meta *types.MsgMeta) (string, error) {
+	newId := types.NewUUID()
+	_, account := ipAccountFromContext(ctx)
+	if meta == nil {
+		meta = &types.MsgMeta{}
+	}
+
+	unsignedCid := smsg.Message.Cid()
+	signedCid := smsg.Cid()
+	signature := smsg.Signature
+	// signed out of messager, the time imported is the earliest time known
+	signedAt := time.Now()
+	msg := &types.Message{
+		ID:              newId.String(),
+		UnsignedCid:     &unsignedCid,
+		SignedCid:       &signedCid,
+		UnsignedMessage: smsg.Message,
+		Signature:       &signature,
+		SignedAt:        &signedAt,
+		Meta:            meta,
+		Receipt:         &venusTypes.MessageReceipt{ExitCode: -1},
+		State:           types.FillMsg,
+		WalletName:      types.ExternalSignerWallet,
+		FromUser:        account,
+	}
+	saved, err := ms.importSignedMessage(ctx, msg)
+	if saved {
+		ms.messageState.SetMessage(msg.ID, msg)
+		ms.log.Infof("import signed message %s, from %s nonce %d", msg.ID, msg.From, msg.Nonce)
+		ms.multiNodeToPush(ctx, []*venusTypes.SignedMessage{smsg})
+	}
+	if err != nil {
+		ms.log.Errorf("push signed message %s failed %v", newId.String(), err)
+		return newId.String(), err
+	}
+
+	return newId.String(), nil
+}
+
+// importSignedMessage verify the signature and nonce of message, push it to the node, then save it and advance the nonce
+// of address, the nonce must be the next nonce of address, which is the bigger one of nonce in database and on chain.
+// the message rejected by the node is not saved, or it would hold the nonce of address forever, but it is saved if the
+// node is unavailable, which may have received it, saved is true then with the push error
+func (ms *MessageService) importSignedMessage(ctx context.Context, msg *types.Message) (bool, error) {
+	if msg.From.Protocol() == address.ID {
+		return false, xerrors.Errorf("from address %s of signed message must be a key address", msg.From)
+	}
+	if err := verifySignature(msg.Signature, msg.From, msg.UnsignedCid.Bytes()); err != nil {
+		return false, xerrors.Errorf("verify signature failed %v", err)
+	}
+
+	// address out of scope of the account is not found either
+	_, err := ms.addressService.GetAddress(ctx, msg.From)
+	newAddr := err != nil
+	if err != nil {
+		// addresses are added by binding when binding required
+		if !xerrors.Is(err, gorm.ErrRecordNotFound) || ms.cfg.RequireAddressBinding {
+			return false, err
+		}
+	}
+	if ms.cfg.RequireAddressBinding {
+		if err := ms.addressService.checkAddressBinding(ctx, msg.FromUser, msg.From, msg.Method); err != nil {
+			return false, err
+		}
+	}
+	if err := ms.checkBacklog(msg); err != nil {
+		return false, err
+	}
+	actor, err := ms.nodeClient.StateGetActor(ctx, msg.From, venusTypes.EmptyTSK)
+	if err != nil {
+		return false, xerrors.Errorf("get actor of %s failed %v", msg.From, err)
+	}
+
+	smsg := &venusTypes.SignedMessage{Message: msg.UnsignedMessage, Signature: *msg.Signature}
+	var pushErr error
+	err = ms.limitService.admitPush(ctx, msg, func() error {
+		ms.nonceLk.Lock()
+		defer ms.nonceLk.Unlock()
+
+		addrInfo, err := ms.repo.AddressRepo().GetAddress(ctx, msg.From)
+		if err == nil && newAddr {
+			return xerrors.Errorf("address %s %w", msg.From, gorm.ErrRecordNotFound)
+		}
+		if err != nil {
+			if !xerrors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			addrInfo = nil
+		}
+		if addrInfo != nil && addrInfo.State == types.Forbiden {
+			return xerrors.Errorf("address(%s) is forbidden", msg.From.String())
+		}
+		nextNonce := actor.Nonce
+		if addrInfo != nil && addrInfo.Nonce > nextNonce {
+			nextNonce = addrInfo.Nonce
+		}
+		if msg.Nonce != nextNonce {
+			return xerrors.Errorf("nonce %d of message not match the next nonce %d of address %s, on chain %d",
+				msg.Nonce, nextNonce, msg.From, actor.Nonce)
+		}
+
+		// the nonce is held by the lock while pushing, nothing else takes it
+		pushCtx, cancel := context.WithTimeout(ctx, nodePushTimeout)
+		_, pushErr = ms.nodeClient.MpoolPush(pushCtx, smsg)
+		cancel()
+		if pushErr != nil && !nodeUnavailable(pushErr) {
+			return xerrors.Errorf("message rejected by node %v", pushErr)
+		}
+
+		return ms.repo.Transaction(func(txRepo repo.TxRepo) error {
+			if addrInfo == nil {
+				addrInfo = &types.Address{
+					ID:        types.NewUUID(),
+					Addr:      msg.From,
+					Nonce:     actor.Nonce,
+					State:     types.Alive,
+					IsDeleted: repo.NotDeleted,
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				}
+				if err := txRepo.AddressRepo().SaveAddress(ctx, addrInfo); err != nil {
+					return xerrors.Errorf("save address %s failed %v", msg.From.String(), err)
+				}
+				ms.log.Infof("add new address %s", msg.From.String())
+			}
+			if err := txRepo.MessageRepo().CreateMessage(msg); err != nil {
+				return err
+			}
+			return txRepo.AddressRepo().UpdateNonce(ctx, msg.From, msg.Nonce+1)
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	ms.saveBroadcastResults([]*types.BroadcastResult{newBroadcastResult(smsg, DefaultNodeName, pushErr)})
+	if pushErr != nil {
+		// it will be republished by mpool watcher or manually
+		return true, xerrors.Errorf("message %s saved but push to node failed %v", msg.ID, pushErr)
+	}
+	return true, nil
+}
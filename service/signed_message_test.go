@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/venus-wallet/core"
+	venusCrypto "github.com/filecoin-project/venus/pkg/crypto"
+	venustypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs-force-community/venus-gateway/walletevent"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/gateway"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func signSecp256k1(t *testing.T, key []byte, msg *types.Message) {
+	unsignedCid := msg.UnsignedMessage.Cid()
+	sig, err := venusCrypto.Sign(unsignedCid.Bytes(), key, crypto.SigTypeSecp256k1)
+	assert.NoError(t, err)
+	msg.UnsignedCid = &unsignedCid
+	msg.Signature = sig
+}
+
+func TestImportSignedMessage(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "signed_message.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("signed_message.db"))
+		assert.NoError(t, os.Remove("signed_message.db-shm"))
+		assert.NoError(t, os.Remove("signed_message.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	key, err := venusCrypto.Generate(crypto.SigTypeSecp256k1)
+	assert.NoError(t, err)
+	pubKey, err := venusCrypto.ToPublic(crypto.SigTypeSecp256k1, key)
+	assert.NoError(t, err)
+	from, err := address.NewSecp256k1Address(pubKey)
+	assert.NoError(t, err)
+
+	chainNonce := uint64(3)
+	var pushErr error
+	cfg := &config.MessageServiceConfig{}
+	ms := &MessageService{
+		repo:           db,
+		log:            log.New(),
+		cfg:            cfg,
+		addressService: &AddressService{repo: db, log: log.New(), cfg: cfg},
+		limitService:   NewAccountLimitService(db, log.New()),
+		nodeClient: &NodeClient{
+			StateGetActor: func(context.Context, address.Address, venustypes.TipSetKey) (*venustypes.Actor, error) {
+				return &venustypes.Actor{Nonce: chainNonce}, nil
+			},
+			MpoolPush: func(context.Context, *venustypes.SignedMessage) (cid.Cid, error) {
+				return cid.Undef, pushErr
+			},
+		},
+	}
+	ctx := context.Background()
+	importMsg := func(msg *types.Message) error {
+		_, err := ms.importSignedMessage(ctx, msg)
+		return err
+	}
+
+	newMsg := func(nonce uint64) *types.Message {
+		msg := models.NewMessage()
+		msg.From = from
+		msg.Nonce = nonce
+		msg.State = types.FillMsg
+		signSecp256k1(t, key, msg)
+		return msg
+	}
+
+	// address not in database, nonce on chain is used
+	assert.Error(t, importMsg(newMsg(0)))
+	msg := newMsg(chainNonce)
+	assert.NoError(t, importMsg(msg))
+	addrInfo, err := db.AddressRepo().GetAddress(ctx, from)
+	assert.NoError(t, err)
+	assert.Equal(t, chainNonce+1, addrInfo.Nonce)
+
+	saved, err := db.MessageRepo().GetMessageByFromAndNonce(from, chainNonce)
+	assert.NoError(t, err)
+	assert.Equal(t, msg.ID, saved.ID)
+	assert.Equal(t, types.FillMsg, saved.State)
+
+	// nonce taken
+	assert.Error(t, importMsg(newMsg(chainNonce)))
+
+	// signature not match the message
+	msg = newMsg(chainNonce + 1)
+	msg.Value = big.Add(msg.Value, big.NewInt(1))
+	unsignedCid := msg.UnsignedMessage.Cid()
+	msg.UnsignedCid = &unsignedCid
+	assert.Error(t, importMsg(msg))
+
+	// next nonce of address in database
+	assert.NoError(t, importMsg(newMsg(chainNonce+1)))
+	addrInfo, err = db.AddressRepo().GetAddress(ctx, from)
+	assert.NoError(t, err)
+	assert.Equal(t, chainNonce+2, addrInfo.Nonce)
+
+	// messages selected while the nonce advanced by imported messages are dropped
+	selected := newMsg(chainNonce + 1)
+	selectResult := &MsgSelectResult{
+		SelectMsg:     []*types.Message{selected},
+		ModifyAddress: []*types.Address{{Addr: from, Nonce: chainNonce + 2}},
+		StartNonce:    map[address.Address]uint64{from: chainNonce + 1},
+	}
+	assert.NoError(t, ms.dropNonceConflict(ctx, db, selectResult))
+	assert.Empty(t, selectResult.SelectMsg)
+	assert.Empty(t, selectResult.ModifyAddress)
+	selectResult = &MsgSelectResult{
+		SelectMsg:     []*types.Message{newMsg(chainNonce + 2)},
+		ModifyAddress: []*types.Address{{Addr: from, Nonce: chainNonce + 3}},
+		StartNonce:    map[address.Address]uint64{from: chainNonce + 2},
+	}
+	assert.NoError(t, ms.dropNonceConflict(ctx, db, selectResult))
+	assert.Len(t, selectResult.SelectMsg, 1)
+	assert.Len(t, selectResult.ModifyAddress, 1)
+
+	// rejected by node, the nonce is not taken
+	pushErr = xerrors.New("gas fee cap too low")
+	msg = newMsg(chainNonce + 2)
+	imported, err := ms.importSignedMessage(ctx, msg)
+	assert.Error(t, err)
+	assert.False(t, imported)
+	_, err = db.MessageRepo().GetMessageByUid(msg.ID)
+	assert.Error(t, err)
+	addrInfo, err = db.AddressRepo().GetAddress(ctx, from)
+	assert.NoError(t, err)
+	assert.Equal(t, chainNonce+2, addrInfo.Nonce)
+
+	// node unavailable, the message may be received by node
+	pushErr = context.DeadlineExceeded
+	msg = newMsg(chainNonce + 2)
+	imported, err = ms.importSignedMessage(ctx, msg)
+	assert.Error(t, err)
+	assert.True(t, imported)
+	_, err = db.MessageRepo().GetMessageByUid(msg.ID)
+	assert.NoError(t, err)
+	pushErr = nil
+
+	// high-water mark reached
+	for _, unfilled := range models.NewMessages(2) {
+		unfilled.From = from
+		assert.NoError(t, db.MessageRepo().CreateMessage(unfilled))
+	}
+	cfg.AddrHighWaterMark = 2
+	err = importMsg(newMsg(chainNonce + 3))
+	assert.True(t, xerrors.Is(err, types.ErrBacklogFull))
+	cfg.AddrHighWaterMark = 0
+
+	assert.NoError(t, db.AddressRepo().UpdateState(ctx, from, types.Forbiden))
+	assert.Error(t, importMsg(newMsg(chainNonce+3)))
+}
+
+// mockWallet hold keys of addresses for accounts
+type mockWallet struct {
+	keys map[string]map[address.Address][]byte
+}
+
+var _ gateway.IWalletClient = (*mockWallet)(nil)
+
+func (w *mockWallet) WalletHas(ctx context.Context, account string, addr address.Address) (bool, error) {
+	_, ok := w.keys[account][addr]
+	return ok, nil
+}
+
+func (w *mockWallet) WalletSign(ctx context.Context, account string, addr address.Address, toSign []byte, meta core.MsgMeta) (*crypto.Signature, error) {
+	key, ok := w.keys[account][addr]
+	if !ok {
+		return nil, xerrors.Errorf("address %s not found in wallet of %s", addr, account)
+	}
+	return venusCrypto.Sign(toSign, key, crypto.SigTypeSecp256k1)
+}
+
+func (w *mockWallet) ListWalletInfo(ctx context.Context) ([]*walletevent.WalletDetail, error) {
+	return nil, nil
+}
+
+func TestReplaceImportedMessage(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "replace_imported.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("replace_imported.db"))
+		assert.NoError(t, os.Remove("replace_imported.db-shm"))
+		assert.NoError(t, os.Remove("replace_imported.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	key, err := venusCrypto.Generate(crypto.SigTypeSecp256k1)
+	assert.NoError(t, err)
+	pubKey, err := venusCrypto.ToPublic(crypto.SigTypeSecp256k1, key)
+	assert.NoError(t, err)
+	from, err := address.NewSecp256k1Address(pubKey)
+	assert.NoError(t, err)
+
+	msgState, err := NewMessageState(db, log.New(), &config.MessageStateConfig{
+		BackTime:          60,
+		CleanupInterval:   3,
+		DefaultExpiration: 2,
+	})
+	assert.NoError(t, err)
+	wallet := &mockWallet{keys: map[string]map[address.Address][]byte{}}
+	nodeClient := &NodeClient{
+		ChainHead: func(context.Context) (*venustypes.TipSet, error) {
+			return newMockTipSet(t, 10), nil
+		},
+		MpoolBatchPush: func(context.Context, []*venustypes.SignedMessage) ([]cid.Cid, error) {
+			return nil, nil
+		},
+	}
+	ms := &MessageService{
+		repo:          db,
+		log:           log.New(),
+		messageState:  msgState,
+		walletClient:  wallet,
+		nodeClient:    nodeClient,
+		policyService: NewSignPolicyService(db, log.New(), nodeClient),
+	}
+	ctx := context.Background()
+
+	msg := models.NewMessage()
+	msg.From = from
+	msg.FromUser = "user"
+	msg.State = types.FillMsg
+	msg.WalletName = types.ExternalSignerWallet
+	signSecp256k1(t, key, msg)
+	assert.NoError(t, db.MessageRepo().CreateMessage(msg))
+
+	// wallet of the account has no key
+	_, err = ms.ReplaceMessage(ctx, msg.ID, false, "", 0, "200", "3000")
+	assert.Error(t, err)
+
+	wallet.keys["user"] = map[address.Address][]byte{from: key}
+	_, err = ms.ReplaceMessage(ctx, msg.ID, false, "", 0, "200", "3000")
+	assert.NoError(t, err)
+	replaced, err := db.MessageRepo().GetMessageByUid(msg.ID)
+	assert.NoError(t, err)
+	assert.False(t, replaced.SignedExternally())
+	assert.Equal(t, "user", replaced.WalletName)
+	assert.Equal(t, big.NewInt(200), replaced.GasPremium)
+	assert.NoError(t, verifySignature(replaced.Signature, from, replaced.UnsignedCid.Bytes()))
+}
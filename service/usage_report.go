@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+// GetUsageReport count messages landed on chain in the period by height, and messages not on chain by the created time
+func (ms *MessageService) GetUsageReport(ctx context.Context, params *types.UsageReportParams) ([]*types.AccountUsage, error) {
+	startHeight, endHeight, err := ms.heightRange(ctx, params.Start, params.End)
+	if err != nil {
+		return nil, err
+	}
+	account := params.Account
+	if scopedAccount, ok := scopedAccountFromContext(ctx); ok {
+		account = scopedAccount
+	}
+
+	agg := newUsageAggregator()
+	if err := ms.repo.MessageRepo().ScanOnChainMessage(account, startHeight, endHeight, func(msg *types.Message) error {
+		agg.add(msg)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// replaced messages are landed on chain, they are counted by height above
+	msgs, err := ms.repo.MessageRepo().ListOffChainMessageByTime(account, params.Start, params.End)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		agg.add(msg)
+	}
+
+	return agg.result(), nil
+}
+
+// usageAggregator sum the usage of messages by account
+type usageAggregator struct {
+	usages map[string]*types.AccountUsage
+}
+
+func newUsageAggregator() *usageAggregator {
+	return &usageAggregator{usages: make(map[string]*types.AccountUsage)}
+}
+
+func (agg *usageAggregator) add(msg *types.Message) {
+	usage, ok := agg.usages[msg.FromUser]
+	if !ok {
+		usage = &types.AccountUsage{
+			Account:            msg.FromUser,
+			BaseFeeBurn:        big.Zero(),
+			OverEstimationBurn: big.Zero(),
+			MinerTip:           big.Zero(),
+			TotalFee:           big.Zero(),
+		}
+		agg.usages[msg.FromUser] = usage
+	}
+	usage.MsgCount++
+
+	switch msg.State {
+	case types.OnChainMsg, types.ReplacedMsg:
+		if msg.Receipt != nil && msg.Receipt.ExitCode == exitcode.Ok {
+			usage.SuccessCount++
+		} else {
+			usage.FailedCount++
+		}
+	case types.FailedMsg:
+		usage.FailedCount++
+	default:
+		usage.PendingCount++
+	}
+
+	if msg.Receipt != nil && msg.Receipt.GasUsed > 0 {
+		usage.GasUsed += msg.Receipt.GasUsed
+	}
+	if msg.Fee != nil {
+		usage.BaseFeeBurn = big.Add(usage.BaseFeeBurn, msg.Fee.BaseFeeBurn)
+		usage.OverEstimationBurn = big.Add(usage.OverEstimationBurn, msg.Fee.OverEstimationBurn)
+		usage.MinerTip = big.Add(usage.MinerTip, msg.Fee.MinerTip)
+		usage.TotalFee = big.Add(usage.TotalFee, msg.Fee.TotalFee())
+	}
+}
+
+func (agg *usageAggregator) result() []*types.AccountUsage {
+	result := make([]*types.AccountUsage, 0, len(agg.usages))
+	for _, usage := range agg.usages {
+		if finished := usage.SuccessCount + usage.FailedCount; finished > 0 {
+			usage.SuccessRate = float64(usage.SuccessCount) / float64(finished)
+			usage.FailureRate = float64(usage.FailedCount) / float64(finished)
+		}
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Account < result[j].Account
+	})
+
+	return result
+}
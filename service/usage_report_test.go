@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus-messager/config"
+	"github.com/filecoin-project/venus-messager/log"
+	"github.com/filecoin-project/venus-messager/models"
+	"github.com/filecoin-project/venus-messager/models/sqlite"
+	"github.com/filecoin-project/venus-messager/types"
+)
+
+func TestAggregateUsage(t *testing.T) {
+	fee := &types.MsgFee{
+		BaseFee:            big.NewInt(100),
+		BaseFeeBurn:        big.NewInt(1000),
+		OverEstimationBurn: big.NewInt(10),
+		MinerTip:           big.NewInt(100),
+	}
+	msgs := []*types.Message{
+		{FromUser: "a", State: types.OnChainMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: 0, GasUsed: 10}, Fee: fee},
+		{FromUser: "a", State: types.OnChainMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: 16, GasUsed: 20}, Fee: fee},
+		{FromUser: "a", State: types.OnChainMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: 0, GasUsed: 30}, Fee: fee},
+		{FromUser: "a", State: types.ReplacedMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: 0, GasUsed: 40}, Fee: fee},
+		{FromUser: "a", State: types.FailedMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: -1}},
+		{FromUser: "a", State: types.FillMsg, Receipt: &venusTypes.MessageReceipt{ExitCode: -1}},
+		{FromUser: "b", State: types.UnFillMsg},
+	}
+
+	agg := newUsageAggregator()
+	for _, msg := range msgs {
+		agg.add(msg)
+	}
+	usages := agg.result()
+	assert.Len(t, usages, 2)
+
+	usage := usages[0]
+	assert.Equal(t, "a", usage.Account)
+	assert.Equal(t, 6, usage.MsgCount)
+	assert.Equal(t, 3, usage.SuccessCount)
+	assert.Equal(t, 2, usage.FailedCount)
+	assert.Equal(t, 1, usage.PendingCount)
+	assert.Equal(t, 0.6, usage.SuccessRate)
+	assert.Equal(t, 0.4, usage.FailureRate)
+	assert.Equal(t, int64(100), usage.GasUsed)
+	assert.Equal(t, big.NewInt(4000), usage.BaseFeeBurn)
+	assert.Equal(t, big.NewInt(4440), usage.TotalFee)
+
+	usage = usages[1]
+	assert.Equal(t, "b", usage.Account)
+	assert.Equal(t, 1, usage.PendingCount)
+	assert.Equal(t, float64(0), usage.SuccessRate)
+	assert.Equal(t, big.Zero(), usage.TotalFee)
+}
+
+func TestUsageReportReplacedMessage(t *testing.T) {
+	db, err := sqlite.OpenSqlite(&config.SqliteConfig{File: "usage_report.db"})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Remove("usage_report.db"))
+		assert.NoError(t, os.Remove("usage_report.db-shm"))
+		assert.NoError(t, os.Remove("usage_report.db-wal"))
+	}()
+	assert.NoError(t, db.AutoMigrate())
+
+	localMsg := models.NewMessage()
+	localMsg.FromUser = "a"
+	localMsg.Nonce = 5
+	localMsg.State = types.FillMsg
+	unsignedCid := localMsg.UnsignedMessage.Cid()
+	localMsg.UnsignedCid = &unsignedCid
+	localMsg.SignedCid = &unsignedCid
+	assert.NoError(t, db.MessageRepo().CreateMessage(localMsg))
+
+	genesisTs := uint64(1600000000)
+	genesis := newMockTipSetAt(t, 0, genesisTs)
+	head := newMockTipSetAt(t, 100, genesisTs+100*30)
+	ms := &MessageService{
+		repo: db,
+		log:  log.New(),
+		nodeClient: &NodeClient{
+			ChainHead: func(context.Context) (*venusTypes.TipSet, error) {
+				return head, nil
+			},
+			ChainGetTipSetByHeight: func(context.Context, abi.ChainEpoch, venusTypes.TipSetKey) (*venusTypes.TipSet, error) {
+				return genesis, nil
+			},
+		},
+	}
+
+	// the message lands with a higher premium, replaced by the same call out of messager
+	replacement := localMsg.UnsignedMessage
+	replacement.GasPremium = big.Add(replacement.GasPremium, big.NewInt(1))
+	fee := &types.MsgFee{
+		BaseFee:            big.NewInt(100),
+		BaseFeeBurn:        big.NewInt(1000),
+		OverEstimationBurn: big.NewInt(10),
+		MinerTip:           big.NewInt(100),
+	}
+	tsKeys := map[abi.ChainEpoch]venusTypes.TipSetKey{10: venusTypes.EmptyTSK}
+	replaced, err := ms.updateMessageState(context.Background(), tsKeys, []pendingMessage{
+		{cid: replacement.Cid(), msg: &replacement, height: 10, receipt: &venusTypes.MessageReceipt{GasUsed: 10}, fee: fee},
+	}, map[cid.Cid]struct{}{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplacedMsg, replaced[localMsg.ID].State)
+
+	usages, err := ms.GetUsageReport(context.Background(), &types.UsageReportParams{
+		Start: time.Unix(int64(genesisTs), 0),
+		End:   time.Now().Add(time.Minute),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, usages, 1)
+	assert.Equal(t, "a", usages[0].Account)
+	assert.Equal(t, 1, usages[0].MsgCount)
+	assert.Equal(t, 1, usages[0].SuccessCount)
+	assert.Equal(t, int64(10), usages[0].GasUsed)
+	assert.Equal(t, big.NewInt(1110), usages[0].TotalFee)
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// DefaultLimitAccount is the account whose limit applies to accounts without their own limit
+const DefaultLimitAccount = "*"
+
+// AccountLimit limits the messages pushed by an account, zero means unlimited
+type AccountLimit struct {
+	Account string `json:"account"`
+	// max messages pushed in one minute
+	PushPerMinute int64 `json:"pushPerMinute"`
+	// max messages in UnFillMsg and FillMsg state
+	MaxPendingMsg int64 `json:"maxPendingMsg"`
+	// max bytes of params of one message
+	MaxParamsBytes int64 `json:"maxParamsBytes"`
+
+	CreatedAt time.Time `json:"createAt"`
+	UpdatedAt time.Time `json:"updateAt"`
+}
+
+// AccountQuota is the limit of account and its current usage
+type AccountQuota struct {
+	Account string `json:"account"`
+	// the limit applied to account, nil means unlimited
+	Limit *AccountLimit `json:"limit"`
+
+	// only counted for accounts with limit
+	PushesLastMinute int64 `json:"pushesLastMinute"`
+	PendingMsg       int64 `json:"pendingMsg"`
+}
+
+// AccountWeight is the share of account in messages selected from an address shared by accounts,
+// accounts without weight use DefaultAccountWeight
+type AccountWeight struct {
+	Account string `json:"account"`
+	Weight  int64  `json:"weight"`
+
+	CreatedAt time.Time `json:"createAt"`
+	UpdatedAt time.Time `json:"updateAt"`
+}
+
+const DefaultAccountWeight = 1
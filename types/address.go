@@ -30,6 +30,13 @@ type Address struct {
 	GasOverEstimation float64 `json:"gasOverEstimation"`
 	MaxFee            big.Int `json:"maxFee,omitempty"`
 	MaxFeeCap         big.Int `json:"maxFeeCap"`
+	// empty means use the strategy in shared params
+	FeeStrategy       string  `json:"feeStrategy"`
+	FixedPremium      big.Int `json:"fixedPremium"`
+	BaseFeeMultiplier float64 `json:"baseFeeMultiplier"`
+	PremiumPercentile int     `json:"premiumPercentile"`
+	// non-urgent messages are held while base fee above it, zero means use the threshold in shared params
+	BaseFeeThreshold big.Int `json:"baseFeeThreshold"`
 
 	IsDeleted int       `json:"isDeleted"` // 是否删除 1:是  -1:否
 	CreatedAt time.Time `json:"createAt"`  // 创建时间
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// AddressBinding allows the account to push messages from the address
+type AddressBinding struct {
+	ID      UUID            `json:"id"`
+	Account string          `json:"account"`
+	Addr    address.Address `json:"addr"`
+	// methods the account may use, empty means all methods
+	Methods []abi.MethodNum `json:"methods"`
+
+	CreatedAt time.Time `json:"createAt"`
+	UpdatedAt time.Time `json:"updateAt"`
+}
+
+func (binding *AddressBinding) AllowMethod(method abi.MethodNum) bool {
+	if len(binding.Methods) == 0 {
+		return true
+	}
+	for _, m := range binding.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
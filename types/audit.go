@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+)
+
+// AuditRecord is the record of a call which needs admin permission
+type AuditRecord struct {
+	ID      UUID   `json:"id"`
+	Account string `json:"account"`
+	IP      string `json:"ip"`
+	Method  string `json:"method"`
+	// json of arguments and results, secrets are redacted
+	Args   string `json:"args"`
+	Result string `json:"result"`
+	Error  string `json:"error"`
+
+	CreatedAt time.Time `json:"createAt"`
+}
+
+type AuditListParams struct {
+	// empty means all
+	Account string    `json:"account"`
+	Method  string    `json:"method"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	// 0 means no limit
+	Limit int `json:"limit"`
+}
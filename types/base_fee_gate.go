@@ -0,0 +1,21 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+type BaseFeeGate struct {
+	Height    abi.ChainEpoch        `json:"height"`
+	BaseFee   big.Int               `json:"baseFee"`
+	Threshold big.Int               `json:"threshold"` // global threshold
+	Addresses []*AddressBaseFeeGate `json:"addresses"`
+}
+
+type AddressBaseFeeGate struct {
+	Addr      address.Address `json:"addr"`
+	Threshold big.Int         `json:"threshold"`
+	Gated     bool            `json:"gated"`
+	HeldCount int64           `json:"heldCount"`
+}
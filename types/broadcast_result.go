@@ -0,0 +1,18 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ipfs/go-cid"
+)
+
+// BroadcastResult is the result of pushing a signed message to one node
+type BroadcastResult struct {
+	SignedCid cid.Cid
+	Node      string
+	Accepted  bool
+	Error     string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrCodeBacklogFull is the code of ErrBacklogFull, json rpc only keeps the message of error, so the code is kept in
+// the message for clients to check by IsBacklogFull
+const ErrCodeBacklogFull = "ErrBacklogFull"
+
+// ErrBacklogFull is returned when push rejected by backpressure, the caller can retry later
+var ErrBacklogFull = xerrors.New(ErrCodeBacklogFull + ": message backlog is full, retry later")
+
+// IsBacklogFull check whether err is ErrBacklogFull, works for the error returned by api client too
+func IsBacklogFull(err error) bool {
+	if err == nil {
+		return false
+	}
+	return xerrors.Is(err, ErrBacklogFull) || strings.Contains(err.Error(), ErrCodeBacklogFull+":")
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestIsBacklogFull(t *testing.T) {
+	err := xerrors.Errorf("%w: unfilled messages of address reach the limit", ErrBacklogFull)
+	assert.True(t, IsBacklogFull(err))
+	// only the message is kept after json rpc
+	assert.True(t, IsBacklogFull(xerrors.New(err.Error())))
+
+	assert.False(t, IsBacklogFull(nil))
+	assert.False(t, IsBacklogFull(xerrors.New("message backlog is full")))
+}
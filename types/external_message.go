@@ -0,0 +1,23 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	venusTypes "github.com/filecoin-project/venus/pkg/types"
+	"github.com/ipfs/go-cid"
+)
+
+// ExternalMessage is a message on chain sent from managed address outside the messager
+type ExternalMessage struct {
+	Cid       cid.Cid
+	From      address.Address
+	Nonce     uint64
+	Height    int64
+	TipSetKey venusTypes.TipSetKey
+	Receipt   *venusTypes.MessageReceipt
+	// ConflictMsgID is the id of local message which had the same nonce and was re-queued
+	ConflictMsgID string
+
+	CreatedAt time.Time
+}
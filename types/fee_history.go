@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// FeeHistory records the fee market of one applied tipset, the messages of parent tipset are executed with BaseFee
+type FeeHistory struct {
+	Height    abi.ChainEpoch `json:"height"`
+	TipsetKey string         `json:"tipsetKey"`
+	BaseFee   big.Int        `json:"baseFee"`
+	Timestamp uint64         `json:"timestamp"`
+
+	// median gas premium of all messages in parent tipset
+	MedianPremium big.Int `json:"medianPremium"`
+
+	// the number, total gas limit and total premium(paid to miner) of our own messages
+	MsgCount    int     `json:"msgCount"`
+	GasLimit    int64   `json:"gasLimit"`
+	PremiumPaid big.Int `json:"premiumPaid"`
+}
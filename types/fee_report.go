@@ -0,0 +1,31 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+const (
+	FeeReportByAddress = "address"
+	FeeReportByUser    = "user"
+	FeeReportByMethod  = "method"
+)
+
+type FeeReportParams struct {
+	// one of address, user and method
+	GroupBy string    `json:"groupBy"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// FeeReport is the aggregate fee paid by on chain messages of one address, user or method
+type FeeReport struct {
+	Key                string  `json:"key"`
+	MsgCount           int     `json:"msgCount"`
+	GasUsed            int64   `json:"gasUsed"`
+	BaseFeeBurn        big.Int `json:"baseFeeBurn"`
+	OverEstimationBurn big.Int `json:"overEstimationBurn"`
+	MinerTip           big.Int `json:"minerTip"`
+	TotalFee           big.Int `json:"totalFee"`
+}
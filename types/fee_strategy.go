@@ -0,0 +1,47 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+type FeeStrategyParams struct {
+	Strategy          string  `json:"strategy"`
+	FixedPremium      big.Int `json:"fixedPremium"`
+	BaseFeeMultiplier float64 `json:"baseFeeMultiplier"`
+	PremiumPercentile int     `json:"premiumPercentile"`
+}
+
+// EncodeMethodFeeStrategy encode the strategies by method as comma separated method:strategy
+func EncodeMethodFeeStrategy(strategies map[abi.MethodNum]string) string {
+	items := make([]string, 0, len(strategies))
+	for method, strategy := range strategies {
+		items = append(items, strconv.FormatUint(uint64(method), 10)+":"+strategy)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// DecodeMethodFeeStrategy decode the strategies encoded by EncodeMethodFeeStrategy, invalid items are skipped
+func DecodeMethodFeeStrategy(str string) map[abi.MethodNum]string {
+	if len(str) == 0 {
+		return nil
+	}
+	strategies := make(map[abi.MethodNum]string)
+	for _, item := range strings.Split(str, ",") {
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		method, err := strconv.ParseUint(kv[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		strategies[abi.MethodNum(method)] = kv[1]
+	}
+	return strategies
+}
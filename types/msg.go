@@ -20,6 +20,7 @@ const (
 	FailedMsg
 	ReplacedMsg
 	NoWalletMsg
+	BlockedByPolicyMsg
 )
 
 //						---> FailedMsg <------
@@ -27,6 +28,8 @@ const (
 // 				UnFillMsg ---------------> FillMsg --------> OnChainMsg
 //						|					 |
 //		 NoWalletMsg <---				     ---->ReplacedMsg
+//						|
+//	BlockedByPolicyMsg <---
 //
 
 type MessageWithUID struct {
@@ -46,29 +49,66 @@ type Message struct {
 	Confidence int64
 	Receipt    *venusTypes.MessageReceipt
 	TipSetKey  venusTypes.TipSetKey
+	Fee        *MsgFee
 	Meta       *MsgMeta
 	WalletName string
 	FromUser   string
 
 	State MessageState
+	// time when the message signed, nil if not signed yet
+	SignedAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// ExternalSignerWallet is the wallet name of messages signed out of messager, no wallet of messager can sign them again
+const ExternalSignerWallet = "[external-signer]"
+
+// SignedExternally return true if the message is imported with signature, it can't be replaced or re-signed by messager
+func (m *Message) SignedExternally() bool {
+	return m.WalletName == ExternalSignerWallet
+}
+
 func FromUnsignedMessage(unsignedMsg venusTypes.UnsignedMessage) *Message {
 	return &Message{
 		UnsignedMessage: unsignedMsg,
 	}
 }
 
+// MsgFee is the fee actually paid by an on chain message
+type MsgFee struct {
+	// parent base fee of the tipset which executed the message
+	BaseFee            big.Int `json:"baseFee"`
+	BaseFeeBurn        big.Int `json:"baseFeeBurn"`
+	OverEstimationBurn big.Int `json:"overEstimationBurn"`
+	MinerTip           big.Int `json:"minerTip"`
+}
+
+func (fee *MsgFee) TotalFee() big.Int {
+	return big.Sum(fee.BaseFeeBurn, fee.OverEstimationBurn, fee.MinerTip)
+}
+
 type MsgMeta struct {
 	ExpireEpoch       abi.ChainEpoch `json:"expireEpoch"`
 	GasOverEstimation float64        `json:"gasOverEstimation"`
 	MaxFee            big.Int        `json:"maxFee,omitempty"`
 	MaxFeeCap         big.Int        `json:"maxFeeCap"`
+	Priority          MsgPriority    `json:"priority"`
 }
 
+type MsgPriority int
+
+const (
+	PriorityNormal MsgPriority = iota
+	PriorityHigh
+	PriorityUrgent
+)
+
+// PriorityLow is set to messages accepted with defer policy when backlog exceeds the high-water mark,
+// they are selected after messages of other priorities
+const PriorityLow MsgPriority = -1
+
 func MsgStateToString(state MessageState) string {
 	switch state {
 	case UnFillMsg:
@@ -83,6 +123,8 @@ func MsgStateToString(state MessageState) string {
 		return "ReplacedMsg"
 	case NoWalletMsg:
 		return "NoWalletMsg"
+	case BlockedByPolicyMsg:
+		return "BlockedByPolicy"
 	default:
 		return "UnKnown"
 	}
@@ -1,5 +1,12 @@
 package types
 
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+)
+
 type NodeType int
 
 const (
@@ -8,6 +15,48 @@ const (
 	LightNode
 )
 
+var nodeTypeNames = map[NodeType]string{
+	FullNode:  "full",
+	LightNode: "light",
+}
+
+func (t NodeType) String() string {
+	if name, ok := nodeTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func ParseNodeType(str string) (NodeType, error) {
+	for t, name := range nodeTypeNames {
+		if name == str {
+			return t, nil
+		}
+	}
+	return 0, xerrors.Errorf("unknown node type %s, should be full or light", str)
+}
+
+// NodeRole is what a node is used for
+type NodeRole int
+
+const (
+	// ChainRole is for gas estimation, state queries and head events
+	ChainRole NodeRole = iota
+	// BroadcastRole is for pushing and publishing messages
+	BroadcastRole
+)
+
+// HasRole full node serves all roles, light node and node without type only broadcast messages
+func (t NodeType) HasRole(role NodeRole) bool {
+	switch role {
+	case ChainRole:
+		return t == FullNode
+	case BroadcastRole:
+		return true
+	}
+	return false
+}
+
 type Node struct {
 	ID UUID
 
@@ -15,4 +64,19 @@ type Node struct {
 	URL   string
 	Token string
 	Type  NodeType
+
+	// Health is filled by the health monitor, not saved in database
+	Health *NodeHealth `json:",omitempty"`
+}
+
+// NodeHealth is the result of the latest health check of node
+type NodeHealth struct {
+	Reachable bool
+	Height    abi.ChainEpoch
+	// Lag is the number of epochs behind the best head of all nodes
+	Lag       int64
+	Latency   time.Duration
+	LastError string
+	Healthy   bool
+	CheckedAt time.Time
 }
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/filecoin-project/go-address"
+
+// QueueDepth is the number of messages waiting in messager
+type QueueDepth struct {
+	UnFilled int64 `json:"unFilled"`
+	Filled   int64 `json:"filled"`
+
+	// high-water marks of unfilled messages, 0 means no limit
+	AddrHighWaterMark  uint64 `json:"addrHighWaterMark"`
+	TotalHighWaterMark uint64 `json:"totalHighWaterMark"`
+
+	Addrs []*AddrQueueDepth `json:"addrs"`
+}
+
+type AddrQueueDepth struct {
+	Addr     address.Address `json:"addr"`
+	UnFilled int64           `json:"unFilled"`
+	Filled   int64           `json:"filled"`
+}
@@ -36,6 +36,8 @@ type SendParams struct {
 	Method     abi.MethodNum
 	Params     string
 	ParamsType string // json or hex
+
+	Priority MsgPriority
 }
 
 type MethodMeta struct {
@@ -14,10 +14,25 @@ type SharedParams struct {
 	MaxFeeCap         big.Int        `json:"maxFeeCap"`
 
 	SelMsgNum uint64 `json:"selMsgNum"`
+	// max messages signed in one epoch by all addresses, shared by address weight, 0 means unlimited
+	MaxSignPerEpoch uint64 `json:"maxSignPerEpoch"`
 
 	ScanInterval int `json:"scanInterval"` // second
 
 	MaxEstFailNumOfMsg uint64 `json:"maxEstFailNumOfMsg"`
+
+	FeeStrategy       string  `json:"feeStrategy"`
+	FixedPremium      big.Int `json:"fixedPremium"`
+	BaseFeeMultiplier float64 `json:"baseFeeMultiplier"`
+	PremiumPercentile int     `json:"premiumPercentile"`
+	// fee strategy by method number, overrides the strategy of address and the global one,
+	// eg. only SubmitWindowedPoSt(5) uses node-estimate while the others use fixed-premium
+	MethodFeeStrategy map[abi.MethodNum]string `json:"methodFeeStrategy"`
+
+	BaseFeeThreshold big.Int `json:"baseFeeThreshold"`
+
+	// select messages of accounts sharing one address by their weights, otherwise by create time
+	FairShare bool `json:"fairShare"`
 }
 
 func (sp *SharedParams) GetMsgMeta() *MsgMeta {
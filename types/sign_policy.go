@@ -0,0 +1,43 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// SignPolicy limits the messages which can be signed, a message must satisfy all the policies matched with it
+type SignPolicy struct {
+	ID UUID `json:"id"`
+	// the policy applies to messages from Addr, address.Undef means all addresses
+	Addr address.Address `json:"addr"`
+	// the policy applies to messages pushed by Account, empty means all accounts
+	Account string `json:"account"`
+
+	// allowed receivers, empty means no limit
+	AllowedTo []address.Address `json:"allowedTo"`
+	// actor name (eg. storageminer, multisig, account) to the allowed method names of actor,
+	// empty means no limit, otherwise actors not listed are not allowed, empty method names means all methods
+	AllowedMethods map[string][]string `json:"allowedMethods"`
+	// max value of one message, zero means no limit
+	MaxValue big.Int `json:"maxValue"`
+	// max total value of messages signed in 24 hours, zero means no limit
+	MaxDailyValue big.Int `json:"maxDailyValue"`
+	// max fee (fee cap * gas limit) of one message, zero means no limit
+	MaxFee big.Int `json:"maxFee"`
+
+	CreatedAt time.Time `json:"createAt"`
+	UpdatedAt time.Time `json:"updateAt"`
+}
+
+// Match check whether the policy applies to the message from addr pushed by account
+func (policy *SignPolicy) Match(addr address.Address, account string) bool {
+	if policy.Addr != address.Undef && policy.Addr != addr {
+		return false
+	}
+	if len(policy.Account) > 0 && policy.Account != account {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"time"
+)
+
+// LocalToken is the token created by messager, it can be revoked and expires at ExpireAt
+type LocalToken struct {
+	ID   UUID   `json:"id"`
+	Name string `json:"name"`
+	Perm string `json:"perm"`
+	// zero means never expire
+	ExpireAt time.Time `json:"expireAt"`
+	Revoked  bool      `json:"revoked"`
+
+	CreatedAt time.Time `json:"createAt"`
+	UpdatedAt time.Time `json:"updateAt"`
+}
+
+type CreateTokenParams struct {
+	Name string `json:"name"`
+	Perm string `json:"perm"`
+	// zero means never expire
+	Expire time.Duration `json:"expire"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+type UsageReportParams struct {
+	// empty account means all accounts
+	Account string    `json:"account"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// AccountUsage is the usage of one account in a period of time, used to bill the gas consumed
+type AccountUsage struct {
+	Account  string `json:"account"`
+	MsgCount int    `json:"msgCount"`
+	// messages on chain with exit code 0
+	SuccessCount int `json:"successCount"`
+	// failed messages and messages on chain with non-zero exit code
+	FailedCount int `json:"failedCount"`
+	// messages not on chain yet
+	PendingCount int     `json:"pendingCount"`
+	SuccessRate  float64 `json:"successRate"`
+	FailureRate  float64 `json:"failureRate"`
+
+	GasUsed            int64   `json:"gasUsed"`
+	BaseFeeBurn        big.Int `json:"baseFeeBurn"`
+	OverEstimationBurn big.Int `json:"overEstimationBurn"`
+	MinerTip           big.Int `json:"minerTip"`
+	TotalFee           big.Int `json:"totalFee"`
+}